@@ -14,7 +14,10 @@ func main() {
 	}
 
 	// Create and run application
-	application := app.New(cfg)
+	application, err := app.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create application: %v", err)
+	}
 	if err := application.Run(); err != nil {
 		log.Fatalf("Application failed: %v", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,14 +13,30 @@ import (
 	"order-service/internal/config"
 	"order-service/internal/delivery/grpc/handler"
 	"order-service/internal/delivery/grpc/proto"
-	"order-service/internal/domain/entities"
+	httphandler "order-service/internal/delivery/http/handler"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/infrastructure/boltdb"
+	"order-service/internal/infrastructure/catalog"
 	"order-service/internal/infrastructure/logger"
+	"order-service/internal/infrastructure/memory"
+	"order-service/internal/infrastructure/metrics"
 	"order-service/internal/infrastructure/mongodb"
 	"order-service/internal/infrastructure/nats"
+	"order-service/internal/infrastructure/postgres"
+	"order-service/internal/infrastructure/promo"
+	"order-service/internal/infrastructure/tax"
 	"order-service/internal/usecase"
 
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type App struct {
@@ -27,35 +44,150 @@ type App struct {
 	logger *logger.Logger
 }
 
-func New(cfg *config.Config) *App {
+func New(cfg *config.Config) (*App, error) {
+	log, err := logger.New(cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
 	return &App{
 		cfg:    cfg,
-		logger: logger.NewLogger(),
-	}
+		logger: log,
+	}, nil
 }
 
 func (a *App) Run() error {
 	a.logger.Info("Starting order-service")
 
-	orderRepo, err := a.initMongoDB()
+	shutdownTracing, err := a.initTracing(context.Background())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			a.logger.Warn("Failed to flush traces", "error", err)
+		}
+	}()
+
+	orderStore, err := a.initStorage()
 	if err != nil {
 		return err
 	}
-	defer orderRepo.Close()
+	if closer, ok := orderStore.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
 
 	natsPublisher := a.initNATS()
 	if closer, ok := natsPublisher.(interface{ Close() }); ok {
 		defer closer.Close()
 	}
 
-	orderUseCase := usecase.NewOrderUseCase(orderRepo, natsPublisher)
+	stopRelay := a.startOutboxRelay(orderStore, natsPublisher)
+	defer stopRelay()
+
+	useCaseOpts := []usecase.Option{
+		usecase.WithSupportedCurrencies(a.cfg.Orders.SupportedCurrencies...),
+		usecase.WithItemLimits(usecase.ItemLimits{
+			MaxLines:         a.cfg.Orders.MaxLines,
+			MaxQuantity:      a.cfg.Orders.MaxItemQuantity,
+			MaxTotalQuantity: a.cfg.Orders.MaxTotalQuantity,
+		}),
+		usecase.WithMetrics(metrics.OrderMetrics{}),
+		usecase.WithLogger(a.logger.Logger),
+	}
+
+	productCatalog, err := a.initCatalog()
+	if err != nil {
+		return err
+	}
+	if productCatalog != nil {
+		if closer, ok := productCatalog.(interface{ Close() error }); ok {
+			defer closer.Close()
+		}
+		useCaseOpts = append(useCaseOpts,
+			usecase.WithProductCatalog(productCatalog, usecase.PricePolicy(a.cfg.Catalog.PricePolicy)))
+	}
+
+	if a.cfg.Orders.PromotionsFile != "" {
+		promotions, err := promo.NewStaticPromotions(a.cfg.Orders.PromotionsFile)
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Loaded promotions", "file", a.cfg.Orders.PromotionsFile)
+		useCaseOpts = append(useCaseOpts, usecase.WithPromotions(promotions))
+	}
+
+	if a.cfg.Orders.TaxRulesFile != "" {
+		taxRules, err := tax.NewStaticRules(a.cfg.Orders.TaxRulesFile)
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Loaded tax rules", "file", a.cfg.Orders.TaxRulesFile)
+		useCaseOpts = append(useCaseOpts, usecase.WithTaxCalculator(taxRules))
+	}
+
+	orderUseCase := usecase.NewOrderUseCase(orderStore, useCaseOpts...)
+
+	healthServer := health.NewServer()
+	stopHealthChecks := a.startHealthChecks(healthServer, a.dependencyChecks(orderStore, natsPublisher))
+	defer stopHealthChecks()
+
+	orderHandler := handler.NewOrderHandler(orderUseCase)
+
+	grpcServer, lis, err := a.initGRPCServer(orderHandler, healthServer)
+	if err != nil {
+		return err
+	}
 
-	grpcServer, lis, err := a.initGRPCServer(orderUseCase)
+	httpServer, httpLis, err := a.initHTTPServer(orderHandler)
 	if err != nil {
+		lis.Close()
 		return err
 	}
 
-	return a.runServerWithGracefulShutdown(grpcServer, lis)
+	return a.runServerWithGracefulShutdown(grpcServer, lis, httpServer, httpLis, healthServer)
+}
+
+// initStorage opens the storage backend selected by STORAGE_BACKEND.
+func (a *App) initStorage() (repositories.OrderStore, error) {
+	switch a.cfg.Storage.Backend {
+	case config.StorageBackendMemory:
+		a.logger.Warn("Using in-memory storage, orders are lost on restart")
+		return memory.NewOrderRepositoryMemory(), nil
+	case config.StorageBackendPostgres:
+		return a.initPostgres()
+	case config.StorageBackendBolt:
+		return a.initBolt()
+	default:
+		return a.initMongoDB()
+	}
+}
+
+func (a *App) initPostgres() (*postgres.OrderRepositoryPostgres, error) {
+	a.logger.Info("Connecting to PostgreSQL")
+
+	orderRepo, err := postgres.NewOrderRepositoryPostgres(a.cfg.Postgres.DSN, a.logger)
+	if err != nil {
+		a.logger.Error("Failed to connect to PostgreSQL", "error", err)
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	a.logger.Info("Connected to PostgreSQL successfully")
+	return orderRepo, nil
+}
+
+func (a *App) initBolt() (*boltdb.OrderRepositoryBolt, error) {
+	a.logger.Info("Opening embedded storage", "path", a.cfg.Storage.BoltPath)
+
+	orderRepo, err := boltdb.NewOrderRepositoryBolt(a.cfg.Storage.BoltPath)
+	if err != nil {
+		a.logger.Error("Failed to open embedded storage", "error", err)
+		return nil, fmt.Errorf("failed to open embedded storage: %w", err)
+	}
+
+	return orderRepo, nil
 }
 
 func (a *App) initMongoDB() (*mongodb.OrderRepositoryMongo, error) {
@@ -71,32 +203,117 @@ func (a *App) initMongoDB() (*mongodb.OrderRepositoryMongo, error) {
 	return orderRepo, nil
 }
 
+// initNATS connects to NATS. It returns nil when NATS_URL is empty or NATS
+// cannot be reached; events then stay in the outbox.
 func (a *App) initNATS() usecase.NatsPublisher {
 	if a.cfg.NATS.URL == "" {
 		a.logger.Info("NATS URL not set, event publishing disabled")
-		return &noopNatsPublisher{}
+		return nil
 	}
 
-	publisher, err := connectToNATSWithRetry(a.cfg.NATS.URL, a.logger, 3, 2*time.Second)
+	publisher, err := connectToNATSWithRetry(a.cfg.NATS.URL, a.cfg.NATS.Mode == config.NATSModeJetStream, a.logger, 3, 2*time.Second)
 	if err != nil {
 		a.logger.Warn("Failed to connect to NATS, continuing without event publishing",
 			"error", err,
 			"url", a.cfg.NATS.URL)
-		return &noopNatsPublisher{}
+		return nil
 	}
 
-	a.logger.Info("Connected to NATS successfully")
+	a.logger.Info("Connected to NATS successfully", "mode", a.cfg.NATS.Mode)
 	return publisher
 }
 
-func (a *App) initGRPCServer(orderUseCase *usecase.OrderUseCase) (*grpc.Server, net.Listener, error) {
-	orderHandler := handler.NewOrderHandler(orderUseCase)
+// initCatalog opens the product catalog selected by CATALOG_SOURCE. It returns
+// nil when no catalog is configured.
+func (a *App) initCatalog() (usecase.ProductCatalog, error) {
+	switch a.cfg.Catalog.Source {
+	case config.CatalogSourceFile:
+		productCatalog, err := catalog.NewStaticCatalog(a.cfg.Catalog.File)
+		if err != nil {
+			return nil, err
+		}
+		a.logger.Info("Loaded product catalog", "file", a.cfg.Catalog.File, "price_policy", a.cfg.Catalog.PricePolicy)
+		return productCatalog, nil
+	case config.CatalogSourceGRPC:
+		productCatalog, err := catalog.NewGRPCCatalog(a.cfg.Catalog.Addr)
+		if err != nil {
+			return nil, err
+		}
+		a.logger.Info("Using product catalog service", "addr", a.cfg.Catalog.Addr, "price_policy", a.cfg.Catalog.PricePolicy)
+		return productCatalog, nil
+	default:
+		a.logger.Warn("Product catalog not configured, trusting client prices")
+		return nil, nil
+	}
+}
 
+// dependencyChecks lists what the health service probes. Storage is critical;
+// NATS is reported separately because the outbox keeps accepting orders while
+// it is down.
+func (a *App) dependencyChecks(store repositories.OrderStore, publisher usecase.NatsPublisher) []dependencyCheck {
+	var checks []dependencyCheck
+
+	if pinger, ok := store.(interface{ Ping(context.Context) error }); ok {
+		check := dependencyCheck{name: "storage", critical: true, ping: pinger.Ping}
+		if a.cfg.Storage.Backend == config.StorageBackendMongo {
+			// Probes set up before other backends existed ask for "mongodb".
+			check.aliases = []string{"mongodb"}
+		}
+		checks = append(checks, check)
+	}
+
+	if pinger, ok := publisher.(interface{ Ping(context.Context) error }); ok {
+		checks = append(checks, dependencyCheck{name: "nats", ping: pinger.Ping})
+	}
+
+	return checks
+}
+
+// startOutboxRelay runs the outbox relay in the background and returns a function
+// that stops it and waits for the in-flight batch to finish. Without a
+// publisher the relay is not started, so that events stay pending in the
+// outbox until the service runs with NATS again rather than being dropped.
+func (a *App) startOutboxRelay(outbox repositories.OutboxRepository, publisher usecase.NatsPublisher) func() {
+	if publisher == nil {
+		a.logger.Warn("Outbox relay not started, events stay in the outbox until NATS is available")
+		return func() {}
+	}
+
+	relay := nats.NewOutboxRelay(outbox, publisher, a.logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		a.logger.Info("Starting outbox relay")
+		relay.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		a.logger.Info("Outbox relay stopped")
+	}
+}
+
+// unaryInterceptors are run around every call of the order service, whether
+// it arrives over gRPC or through the HTTP API.
+func (a *App) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		metrics.UnaryServerInterceptor(),
+		a.loggingInterceptor(),
+	}
+}
+
+func (a *App) initGRPCServer(orderHandler *handler.OrderHandler, healthServer *health.Server) (*grpc.Server, net.Listener, error) {
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(a.loggingInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(a.unaryInterceptors()...),
 	)
 
 	proto.RegisterOrderServiceServer(grpcServer, orderHandler)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	reflection.Register(grpcServer)
 
 	lis, err := net.Listen("tcp", ":"+a.cfg.GRPC.Port)
@@ -107,30 +324,77 @@ func (a *App) initGRPCServer(orderUseCase *usecase.OrderUseCase) (*grpc.Server,
 	return grpcServer, lis, nil
 }
 
-func (a *App) runServerWithGracefulShutdown(grpcServer *grpc.Server, lis net.Listener) error {
-	serverErrors := make(chan error, 1)
+// initHTTPServer exposes the gRPC handler as a JSON REST API for clients that
+// cannot speak gRPC, along with the Prometheus /metrics endpoint.
+func (a *App) initHTTPServer(orderHandler *handler.OrderHandler) (*http.Server, net.Listener, error) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	mux.Handle("/", otelhttp.NewHandler(
+		a.loggingMiddleware(httphandler.NewOrderHandler(orderHandler, a.unaryInterceptors()...).Routes()),
+		"http",
+		// Name spans after the matched route, e.g. "GET /v1/orders/{id}".
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			if r.Pattern != "" && r.Pattern != "/" {
+				return r.Pattern
+			}
+			return operation
+		}),
+	))
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	lis, err := net.Listen("tcp", ":"+a.cfg.HTTP.Port)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on port %s: %w", a.cfg.HTTP.Port, err)
+	}
+
+	return httpServer, lis, nil
+}
+
+func (a *App) runServerWithGracefulShutdown(grpcServer *grpc.Server, lis net.Listener, httpServer *http.Server, httpLis net.Listener, healthServer *health.Server) error {
+	serverErrors := make(chan error, 2)
 
 	go func() {
 		a.logger.Info("Starting gRPC server", "port", a.cfg.GRPC.Port)
 		serverErrors <- grpcServer.Serve(lis)
 	}()
 
+	go func() {
+		a.logger.Info("Starting HTTP server", "port", a.cfg.HTTP.Port)
+		if err := httpServer.Serve(httpLis); err != http.ErrServerClosed {
+			serverErrors <- err
+		}
+	}()
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
 	select {
 	case err := <-serverErrors:
+		grpcServer.Stop()
+		httpServer.Close()
 		return fmt.Errorf("server error: %w", err)
 
 	case sig := <-shutdown:
 		a.logger.Info("Received shutdown signal, starting graceful shutdown", "signal", sig)
 
+		// Report NOT_SERVING so load balancers stop routing new requests here.
+		healthServer.Shutdown()
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		shutdownComplete := make(chan struct{})
 
 		go func() {
+			a.logger.Info("Stopping HTTP server gracefully")
+			if err := httpServer.Shutdown(ctx); err != nil {
+				a.logger.Warn("HTTP server shutdown interrupted", "error", err)
+			}
+
 			a.logger.Info("Stopping gRPC server gracefully")
 			grpcServer.GracefulStop()
 			close(shutdownComplete)
@@ -141,6 +405,7 @@ func (a *App) runServerWithGracefulShutdown(grpcServer *grpc.Server, lis net.Lis
 			a.logger.Info("Graceful shutdown completed")
 		case <-ctx.Done():
 			a.logger.Warn("Graceful shutdown timeout, forcing stop")
+			httpServer.Close()
 			grpcServer.Stop()
 		}
 
@@ -148,9 +413,9 @@ func (a *App) runServerWithGracefulShutdown(grpcServer *grpc.Server, lis net.Lis
 	}
 }
 
-func connectToNATSWithRetry(url string, logger *logger.Logger, maxRetries int, delay time.Duration) (usecase.NatsPublisher, error) {
+func connectToNATSWithRetry(url string, jetStream bool, logger *logger.Logger, maxRetries int, delay time.Duration) (usecase.NatsPublisher, error) {
 	for i := 0; i < maxRetries; i++ {
-		publisher, err := nats.NewNatsPublisher(url, logger)
+		publisher, err := nats.NewNatsPublisher(url, jetStream, logger)
 		if err == nil {
 			return publisher, nil
 		}
@@ -168,24 +433,87 @@ func connectToNATSWithRetry(url string, logger *logger.Logger, maxRetries int, d
 	return nil, fmt.Errorf("failed to connect to NATS after %d attempts", maxRetries)
 }
 
-type noopNatsPublisher struct{}
-
-func (n *noopNatsPublisher) PublishOrderCreated(ctx context.Context, order *entities.Order) error {
-	return nil
-}
-
-func (n *noopNatsPublisher) Close() {
-}
+// requestIDHeader carries the request ID in gRPC metadata and HTTP headers. A
+// caller-supplied ID is kept so logs can be correlated across services.
+const requestIDHeader = "x-request-id"
 
+// loggingInterceptor logs every call and stores the request ID, method and,
+// when the request has one, user ID in the context, so that everything logged
+// while handling the call carries them.
 func (a *App) loggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		a.logger.Info("gRPC method called", "method", info.FullMethod)
+		requestID := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+		ctx = logger.WithFields(ctx, "request_id", requestID, "method", info.FullMethod)
+		if r, ok := req.(interface{ GetUserId() string }); ok && r.GetUserId() != "" {
+			ctx = logger.WithFields(ctx, "user_id", r.GetUserId())
+		}
+
+		start := time.Now()
+		a.logger.DebugContext(ctx, "gRPC method called")
+
 		resp, err := handler(ctx, req)
-		if err != nil {
-			a.logger.Error("gRPC method failed", "method", info.FullMethod, "error", err)
-		} else {
-			a.logger.Info("gRPC method completed", "method", info.FullMethod)
+
+		duration := time.Since(start)
+		switch code := status.Code(err); code {
+		case codes.OK:
+			a.logger.InfoContext(ctx, "gRPC method completed", "duration", duration)
+		case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded:
+			a.logger.ErrorContext(ctx, "gRPC method failed", "code", code.String(), "error", err, "duration", duration)
+		default:
+			a.logger.WarnContext(ctx, "gRPC method rejected", "code", code.String(), "error", err, "duration", duration)
 		}
+
 		return resp, err
 	}
 }
+
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return uuid.New().String()
+}
+
+func (a *App) loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+
+		// loggingInterceptor stores the request ID in the context of the call
+		// it serves; the lines logged here carry it themselves.
+		fields := []any{"request_id", requestID, "method", r.Method, "path", r.URL.Path}
+		ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs(requestIDHeader, requestID))
+		r = r.WithContext(ctx)
+
+		start := time.Now()
+		a.logger.DebugContext(ctx, "HTTP request received", fields...)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		fields = append(fields, "status", rec.status, "duration", time.Since(start))
+		if rec.status >= http.StatusInternalServerError {
+			a.logger.ErrorContext(ctx, "HTTP request failed", fields...)
+		} else {
+			a.logger.InfoContext(ctx, "HTTP request completed", fields...)
+		}
+	})
+}
+
+// statusRecorder captures the response status for logging.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/config"
+	"order-service/internal/domain/entities"
+	"order-service/internal/infrastructure/logger"
+	"order-service/internal/infrastructure/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxStaysPendingWithoutNATS(t *testing.T) {
+	a := &App{cfg: &config.Config{}, logger: logger.NewLogger()}
+	store := memory.NewOrderRepositoryMemory()
+	ctx := context.Background()
+
+	order := &entities.Order{OrderID: "order-1", UserID: "user123", Status: "PENDING", CreatedAt: time.Now(), Version: 1}
+	event := &entities.OrderEvent{EventID: "e1", Type: entities.OrderEventCreated, Order: order, OccurredAt: time.Now()}
+	require.NoError(t, store.Create(ctx, order, event))
+
+	publisher := a.initNATS()
+	assert.Nil(t, publisher)
+
+	stopRelay := a.startOutboxRelay(store, publisher)
+	// A running relay delivers pending events right away.
+	time.Sleep(50 * time.Millisecond)
+	stopRelay()
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "e1", records[0].Event.EventID)
+}
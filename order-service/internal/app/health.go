@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/delivery/grpc/proto"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthCheckInterval = 5 * time.Second
+	healthCheckTimeout  = 2 * time.Second
+)
+
+// dependencyCheck probes one external dependency. Each dependency is reported
+// under its own service name; critical ones also drive the overall status and
+// the status of the order service itself. The status is also reported under
+// aliases, names the dependency was known by before.
+type dependencyCheck struct {
+	name     string
+	aliases  []string
+	critical bool
+	ping     func(ctx context.Context) error
+}
+
+// startHealthChecks runs the checks once synchronously, so the health server
+// reflects the real state before the servers start, then keeps polling in the
+// background. The returned function stops polling.
+func (a *App) startHealthChecks(healthServer *health.Server, checks []dependencyCheck) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	statuses := make(map[string]healthpb.HealthCheckResponse_ServingStatus)
+	a.runHealthChecks(ctx, healthServer, checks, statuses)
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.runHealthChecks(ctx, healthServer, checks, statuses)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (a *App) runHealthChecks(ctx context.Context, healthServer *health.Server, checks []dependencyCheck, statuses map[string]healthpb.HealthCheckResponse_ServingStatus) {
+	overall := healthpb.HealthCheckResponse_SERVING
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := check.ping(checkCtx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			if check.critical {
+				overall = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+
+		if previous, ok := statuses[check.name]; ok && previous != status {
+			if err != nil {
+				a.logger.Warn("Dependency became unhealthy", "dependency", check.name, "error", err)
+			} else {
+				a.logger.Info("Dependency recovered", "dependency", check.name)
+			}
+		}
+		statuses[check.name] = status
+
+		healthServer.SetServingStatus(check.name, status)
+		for _, alias := range check.aliases {
+			healthServer.SetServingStatus(alias, status)
+		}
+	}
+
+	healthServer.SetServingStatus("", overall)
+	healthServer.SetServingStatus(proto.OrderService_ServiceDesc.ServiceName, overall)
+}
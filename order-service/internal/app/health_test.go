@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/delivery/grpc/proto"
+	"order-service/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func checkStatus(t *testing.T, healthServer *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	assert.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestHealthChecks(t *testing.T) {
+	a := &App{logger: logger.NewLogger()}
+	healthServer := health.NewServer()
+
+	var mongoErr, natsErr error
+	checks := []dependencyCheck{
+		{name: "storage", aliases: []string{"mongodb"}, critical: true, ping: func(ctx context.Context) error { return mongoErr }},
+		{name: "nats", ping: func(ctx context.Context) error { return natsErr }},
+	}
+	statuses := make(map[string]healthpb.HealthCheckResponse_ServingStatus)
+	orderService := proto.OrderService_ServiceDesc.ServiceName
+
+	a.runHealthChecks(context.Background(), healthServer, checks, statuses)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, healthServer, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, healthServer, orderService))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, healthServer, "mongodb"))
+
+	// A non-critical dependency only affects its own status.
+	natsErr = errors.New("disconnected")
+	a.runHealthChecks(context.Background(), healthServer, checks, statuses)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, "nats"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, healthServer, ""))
+
+	mongoErr = errors.New("no primary")
+	a.runHealthChecks(context.Background(), healthServer, checks, statuses)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, "storage"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, "mongodb"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, orderService))
+
+	// Once shutting down the server stays NOT_SERVING even if checks pass.
+	mongoErr, natsErr = nil, nil
+	healthServer.Shutdown()
+	a.runHealthChecks(context.Background(), healthServer, checks, statuses)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, healthServer, ""))
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"order-service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const serviceName = "order-service"
+
+// initTracing installs the global W3C propagators and, unless tracing is
+// disabled, a tracer provider exporting to the configured backend. Propagation
+// stays on when tracing is disabled, so trace context received from callers
+// still reaches NATS consumers. The returned function flushes pending spans.
+func (a *App) initTracing(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch a.cfg.Tracing.Exporter {
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case config.TracingExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(a.cfg.Tracing.OTLPEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	default:
+		a.logger.Info("Tracing disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", a.cfg.Tracing.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(a.cfg.Tracing.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	a.logger.Info("Tracing enabled", "exporter", a.cfg.Tracing.Exporter, "sample_ratio", a.cfg.Tracing.SampleRatio)
+	return provider.Shutdown, nil
+}
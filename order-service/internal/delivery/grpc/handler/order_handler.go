@@ -2,13 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"order-service/internal/delivery/grpc/proto"
 	"order-service/internal/domain/entities"
 	"order-service/internal/domain/repositories"
 	"order-service/internal/usecase"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -25,17 +30,32 @@ func NewOrderHandler(orderUseCase *usecase.OrderUseCase) *OrderHandler {
 }
 
 func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRequest) (*proto.CreateOrderResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = entities.DefaultCurrency
+	}
+
 	// Конвертация protobuf -> domain entities
-	items := make([]entities.Item, len(req.Items))
-	for i, item := range req.Items {
-		items[i] = entities.Item{
-			ProductID: item.ProductId,
-			Quantity:  int(item.Quantity),
-			Price:     item.Price,
-		}
+	items, err := protoToItems(req.Items, currency)
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
 	}
 
-	order, err := h.orderUseCase.CreateOrder(ctx, req.UserId, items)
+	idempotencyKey := req.IdempotencyKey
+	if idempotencyKey == "" {
+		idempotencyKey = idempotencyKeyFromMetadata(ctx)
+	}
+
+	order, err := h.orderUseCase.CreateOrder(ctx, usecase.CreateOrderInput{
+		UserID:          req.UserId,
+		Currency:        currency,
+		Items:           items,
+		IdempotencyKey:  idempotencyKey,
+		PromoCode:       req.PromoCode,
+		Region:          req.Region,
+		ShippingAddress: protoToShippingAddress(req.ShippingAddress),
+		DeliveryMethod:  protoToDeliveryMethod(req.DeliveryMethod),
+	})
 	if err != nil {
 		return nil, h.mapErrorToStatus(err)
 	}
@@ -44,6 +64,74 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRe
 	return &proto.CreateOrderResponse{Order: protoOrder}, nil
 }
 
+func protoToItems(protoItems []*proto.Item, currency string) ([]entities.Item, error) {
+	items := make([]entities.Item, len(protoItems))
+	for i, item := range protoItems {
+		price, err := protoToPrice(item, currency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d has invalid price", usecase.ErrInvalidItem, i)
+		}
+		items[i] = entities.Item{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+			Price:     price,
+			Category:  item.Category,
+		}
+	}
+	return items, nil
+}
+
+// protoToPrice prefers unit_price and falls back to the deprecated float price
+// for clients that do not send it yet. Prices without a currency are taken to
+// be in the order currency.
+func protoToPrice(item *proto.Item, orderCurrency string) (entities.Money, error) {
+	if item.UnitPrice != nil {
+		currency := item.UnitPrice.Currency
+		if currency == "" {
+			currency = orderCurrency
+		}
+		return entities.NewMoney(item.UnitPrice.Amount, currency), nil
+	}
+	return entities.MoneyFromFloat(item.Price, orderCurrency)
+}
+
+func moneyToProto(m entities.Money) *proto.Money {
+	return &proto.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
+
+func discountsToProto(discounts []entities.Discount) []*proto.Discount {
+	protoDiscounts := make([]*proto.Discount, len(discounts))
+	for i, discount := range discounts {
+		protoDiscounts[i] = &proto.Discount{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			Amount:      moneyToProto(discount.Amount),
+		}
+	}
+	return protoDiscounts
+}
+
+func itemTaxToProto(tax *entities.ItemTax) *proto.ItemTax {
+	if tax == nil {
+		return nil
+	}
+	return &proto.ItemTax{
+		RateBasisPoints: tax.RateBasisPoints,
+		Inclusive:       tax.Inclusive,
+		Amount:          moneyToProto(tax.Amount),
+	}
+}
+
+func idempotencyKeyFromMetadata(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, "idempotency-key"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
 func (h *OrderHandler) GetOrder(ctx context.Context, req *proto.GetOrderRequest) (*proto.GetOrderResponse, error) {
 	order, err := h.orderUseCase.GetOrder(ctx, req.OrderId)
 	if err != nil {
@@ -55,7 +143,13 @@ func (h *OrderHandler) GetOrder(ctx context.Context, req *proto.GetOrderRequest)
 }
 
 func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *proto.UpdateOrderStatusRequest) (*proto.UpdateOrderStatusResponse, error) {
-	order, err := h.orderUseCase.UpdateOrderStatus(ctx, req.OrderId, req.Status)
+	order, err := h.orderUseCase.UpdateOrderStatus(ctx, usecase.UpdateOrderStatusInput{
+		OrderID:         req.OrderId,
+		Status:          req.Status,
+		ChangedBy:       req.ChangedBy,
+		Reason:          req.Reason,
+		ExpectedVersion: req.ExpectedVersion,
+	})
 	if err != nil {
 		return nil, h.mapErrorToStatus(err)
 	}
@@ -64,36 +158,300 @@ func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *proto.UpdateO
 	return &proto.UpdateOrderStatusResponse{Order: protoOrder}, nil
 }
 
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *proto.CancelOrderRequest) (*proto.CancelOrderResponse, error) {
+	order, err := h.orderUseCase.CancelOrder(ctx, usecase.CancelOrderInput{
+		OrderID:         req.OrderId,
+		Reason:          protoToCancelReason(req.Reason),
+		Comment:         req.Comment,
+		CancelledBy:     req.CancelledBy,
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrder := h.domainToProto(order)
+	return &proto.CancelOrderResponse{Order: protoOrder}, nil
+}
+
+// The proto enum names are the domain reason codes prefixed with CANCEL_REASON_.
+const cancelReasonPrefix = "CANCEL_REASON_"
+
+func protoToCancelReason(reason proto.CancelReason) entities.CancelReason {
+	return entities.CancelReason(strings.TrimPrefix(reason.String(), cancelReasonPrefix))
+}
+
+func cancellationToProto(cancellation *entities.Cancellation) *proto.Cancellation {
+	if cancellation == nil {
+		return nil
+	}
+	return &proto.Cancellation{
+		CancelledAt: timestamppb.New(cancellation.CancelledAt),
+		Reason:      proto.CancelReason(proto.CancelReason_value[cancelReasonPrefix+string(cancellation.Reason)]),
+		Comment:     cancellation.Comment,
+		CancelledBy: cancellation.CancelledBy,
+	}
+}
+
+func (h *OrderHandler) UpdateShippingAddress(ctx context.Context, req *proto.UpdateShippingAddressRequest) (*proto.UpdateShippingAddressResponse, error) {
+	order, err := h.orderUseCase.UpdateShippingAddress(ctx, usecase.UpdateShippingAddressInput{
+		OrderID:         req.OrderId,
+		Address:         protoToShippingAddress(req.ShippingAddress),
+		DeliveryMethod:  protoToDeliveryMethod(req.DeliveryMethod),
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrder := h.domainToProto(order)
+	return &proto.UpdateShippingAddressResponse{Order: protoOrder}, nil
+}
+
+func (h *OrderHandler) AddItem(ctx context.Context, req *proto.AddItemRequest) (*proto.AddItemResponse, error) {
+	if req.Item == nil {
+		return nil, h.mapErrorToStatus(fmt.Errorf("%w: item is required", usecase.ErrInvalidItem))
+	}
+
+	// Prices without a currency are filled in with the order currency by the
+	// use case, which knows it.
+	var price entities.Money
+	if req.Item.UnitPrice != nil {
+		price = entities.NewMoney(req.Item.UnitPrice.Amount, req.Item.UnitPrice.Currency)
+	}
+
+	order, err := h.orderUseCase.AddItem(ctx, usecase.AddItemInput{
+		OrderID: req.OrderId,
+		Item: entities.Item{
+			ProductID: req.Item.ProductId,
+			Quantity:  int(req.Item.Quantity),
+			Price:     price,
+			Category:  req.Item.Category,
+		},
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrder := h.domainToProto(order)
+	return &proto.AddItemResponse{Order: protoOrder}, nil
+}
+
+func (h *OrderHandler) RemoveItem(ctx context.Context, req *proto.RemoveItemRequest) (*proto.RemoveItemResponse, error) {
+	order, err := h.orderUseCase.RemoveItem(ctx, usecase.RemoveItemInput{
+		OrderID:         req.OrderId,
+		ProductID:       req.ProductId,
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrder := h.domainToProto(order)
+	return &proto.RemoveItemResponse{Order: protoOrder}, nil
+}
+
+func (h *OrderHandler) ChangeItemQuantity(ctx context.Context, req *proto.ChangeItemQuantityRequest) (*proto.ChangeItemQuantityResponse, error) {
+	order, err := h.orderUseCase.ChangeItemQuantity(ctx, usecase.ChangeItemQuantityInput{
+		OrderID:         req.OrderId,
+		ProductID:       req.ProductId,
+		Quantity:        int(req.Quantity),
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrder := h.domainToProto(order)
+	return &proto.ChangeItemQuantityResponse{Order: protoOrder}, nil
+}
+
+// The proto enum names are the domain delivery methods prefixed with
+// DELIVERY_METHOD_; DELIVERY_METHOD_UNSPECIFIED maps to no method.
+const deliveryMethodPrefix = "DELIVERY_METHOD_"
+
+func protoToDeliveryMethod(method proto.DeliveryMethod) entities.DeliveryMethod {
+	if method == proto.DeliveryMethod_DELIVERY_METHOD_UNSPECIFIED {
+		return ""
+	}
+	return entities.DeliveryMethod(strings.TrimPrefix(method.String(), deliveryMethodPrefix))
+}
+
+func deliveryMethodToProto(method entities.DeliveryMethod) proto.DeliveryMethod {
+	return proto.DeliveryMethod(proto.DeliveryMethod_value[deliveryMethodPrefix+string(method)])
+}
+
+func protoToShippingAddress(address *proto.ShippingAddress) *entities.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+	return &entities.ShippingAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		State:         address.State,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+	}
+}
+
+func shippingAddressToProto(address *entities.ShippingAddress) *proto.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+	return &proto.ShippingAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		State:         address.State,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+	}
+}
+
+func (h *OrderHandler) GetOrderHistory(ctx context.Context, req *proto.GetOrderHistoryRequest) (*proto.GetOrderHistoryResponse, error) {
+	history, err := h.orderUseCase.GetOrderHistory(ctx, req.OrderId)
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	changes := make([]*proto.StatusChange, len(history))
+	for i, change := range history {
+		changes[i] = &proto.StatusChange{
+			FromStatus: change.From,
+			ToStatus:   change.To,
+			ChangedAt:  timestamppb.New(change.ChangedAt),
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+			Version:    change.Version,
+		}
+	}
+
+	return &proto.GetOrderHistoryResponse{Changes: changes}, nil
+}
+
+func (h *OrderHandler) ListOrders(ctx context.Context, req *proto.ListOrdersRequest) (*proto.ListOrdersResponse, error) {
+	filter := usecase.ListOrdersFilter{
+		UserID:   req.UserId,
+		Statuses: req.Statuses,
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	orders, nextPageToken, err := h.orderUseCase.ListOrders(ctx, filter, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, h.mapErrorToStatus(err)
+	}
+
+	protoOrders := make([]*proto.Order, len(orders))
+	for i, order := range orders {
+		protoOrders[i] = h.domainToProto(order)
+	}
+
+	return &proto.ListOrdersResponse{
+		Orders:        protoOrders,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
 func (h *OrderHandler) domainToProto(order *entities.Order) *proto.Order {
 	protoItems := make([]*proto.Item, len(order.Items))
 	for i, item := range order.Items {
 		protoItems[i] = &proto.Item{
 			ProductId: item.ProductID,
 			Quantity:  int32(item.Quantity),
-			Price:     item.Price,
+			Price:     item.Price.Float(),
+			UnitPrice: moneyToProto(item.Price),
+			Category:  item.Category,
+			Tax:       itemTaxToProto(item.Tax),
 		}
 	}
 
 	return &proto.Order{
-		OrderId:     order.OrderID,
-		UserId:      order.UserID,
-		Items:       protoItems,
-		TotalAmount: order.TotalAmount,
-		Status:      order.Status,
-		CreatedAt:   timestamppb.New(order.CreatedAt),
+		OrderId:         order.OrderID,
+		UserId:          order.UserID,
+		Items:           protoItems,
+		TotalAmount:     order.TotalAmount.Float(),
+		Total:           moneyToProto(order.TotalAmount),
+		Subtotal:        moneyToProto(order.Subtotal),
+		PromoCode:       order.PromoCode,
+		Discounts:       discountsToProto(order.Discounts),
+		Region:          order.Region,
+		Tax:             moneyToProto(order.TaxAmount),
+		ShippingAddress: shippingAddressToProto(order.ShippingAddress),
+		DeliveryMethod:  deliveryMethodToProto(order.DeliveryMethod),
+		Currency:        order.Currency,
+		Status:          order.Status,
+		CreatedAt:       timestamppb.New(order.CreatedAt),
+		UpdatedAt:       timestamppb.New(order.UpdatedAt),
+		Version:         order.Version,
+		Cancellation:    cancellationToProto(order.Cancellation),
 	}
 }
 
 func (h *OrderHandler) mapErrorToStatus(err error) error {
-	switch err {
-	case usecase.ErrInvalidUserID, usecase.ErrInvalidOrderID, usecase.ErrEmptyItems,
-		usecase.ErrInvalidItem, usecase.ErrInvalidStatus:
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationStatus(validationErr)
+	}
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidUserID), errors.Is(err, usecase.ErrInvalidOrderID),
+		errors.Is(err, usecase.ErrEmptyItems), errors.Is(err, usecase.ErrInvalidItem),
+		errors.Is(err, usecase.ErrInvalidStatus), errors.Is(err, usecase.ErrInvalidPageSize),
+		errors.Is(err, usecase.ErrInvalidPageToken), errors.Is(err, usecase.ErrInvalidTimeRange),
+		errors.Is(err, usecase.ErrInvalidIdempotencyKey), errors.Is(err, usecase.ErrInvalidCurrency),
+		errors.Is(err, usecase.ErrCurrencyMismatch), errors.Is(err, usecase.ErrInvalidCancellation),
+		errors.Is(err, usecase.ErrInvalidStatusReason), errors.Is(err, usecase.ErrUnknownProduct),
+		errors.Is(err, usecase.ErrUnknownPromoCode), errors.Is(err, entities.ErrNoTaxRule),
+		errors.Is(err, usecase.ErrInvalidShippingAddress), errors.Is(err, usecase.ErrItemLimitExceeded):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case repositories.ErrOrderNotFound:
+	case errors.Is(err, usecase.ErrInvalidTransition), errors.Is(err, usecase.ErrProductUnavailable),
+		errors.Is(err, usecase.ErrPriceMismatch), errors.Is(err, usecase.ErrPromoCodeNotApplicable),
+		errors.Is(err, usecase.ErrShippingNotEditable), errors.Is(err, usecase.ErrItemsNotEditable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrItemNotFound):
 		return status.Error(codes.NotFound, err.Error())
-	case repositories.ErrOrderAlreadyExists:
+	case errors.Is(err, usecase.ErrCatalogUnavailable):
+		return status.Error(codes.Unavailable, usecase.ErrCatalogUnavailable.Error())
+	case errors.Is(err, usecase.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, usecase.ErrIdempotencyKeyReused):
 		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrOrderNotFound):
+		return status.Error(codes.NotFound, repositories.ErrOrderNotFound.Error())
+	case errors.Is(err, repositories.ErrOrderAlreadyExists):
+		return status.Error(codes.AlreadyExists, repositories.ErrOrderAlreadyExists.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
 }
+
+// validationStatus reports every violation of a validation error in a
+// BadRequest detail, so that clients can point at the offending fields.
+func validationStatus(err *usecase.ValidationError) error {
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(err.Violations)),
+	}
+	for i, v := range err.Violations {
+		badRequest.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
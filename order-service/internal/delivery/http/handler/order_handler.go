@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	grpchandler "order-service/internal/delivery/grpc/handler"
+	"order-service/internal/delivery/grpc/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// maxBodyBytes caps request bodies; orders are far smaller than this.
+const maxBodyBytes = 1 << 20
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// OrderHandler exposes the order service as JSON over HTTP. Requests and
+// responses use the protobuf JSON mapping of the gRPC messages, and every call
+// goes through the gRPC handler so validation and error mapping are shared.
+type OrderHandler struct {
+	orders      *grpchandler.OrderHandler
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// NewOrderHandler returns a handler calling orders through interceptors, in
+// the order given, just as the gRPC server chains them, so that HTTP calls
+// are measured and logged like gRPC ones.
+func NewOrderHandler(orders *grpchandler.OrderHandler, interceptors ...grpc.UnaryServerInterceptor) *OrderHandler {
+	return &OrderHandler{
+		orders:      orders,
+		interceptor: chainInterceptors(interceptors),
+	}
+}
+
+func chainInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// invoke calls method of the gRPC handler through the interceptors of h.
+func invoke[Req, Resp any](ctx context.Context, h *OrderHandler, method string, req Req, call func(context.Context, Req) (Resp, error)) (Resp, error) {
+	if h.interceptor == nil {
+		return call(ctx, req)
+	}
+
+	info := &grpc.UnaryServerInfo{Server: h.orders, FullMethod: method}
+	resp, err := h.interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return call(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return resp.(Resp), nil
+}
+
+func (h *OrderHandler) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/orders", h.CreateOrder)
+	mux.HandleFunc("GET /v1/orders/{id}", h.GetOrder)
+	mux.HandleFunc("PATCH /v1/orders/{id}/status", h.UpdateOrderStatus)
+	mux.HandleFunc("POST /v1/orders/{id}/cancel", h.CancelOrder)
+	mux.HandleFunc("GET /v1/orders/{id}/history", h.GetOrderHistory)
+	mux.HandleFunc("PUT /v1/orders/{id}/shipping", h.UpdateShippingAddress)
+	mux.HandleFunc("POST /v1/orders/{id}/items", h.AddItem)
+	mux.HandleFunc("DELETE /v1/orders/{id}/items/{product_id}", h.RemoveItem)
+	mux.HandleFunc("PATCH /v1/orders/{id}/items/{product_id}", h.ChangeItemQuantity)
+	return mux
+}
+
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	req := &proto.CreateOrderRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+
+	// The gRPC handler reads the key from metadata when it is not in the body.
+	ctx := r.Context()
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set("idempotency-key", key)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	resp, err := invoke(ctx, h, proto.OrderService_CreateOrder_FullMethodName, req, h.orders.CreateOrder)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusCreated, resp.Order)
+}
+
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	resp, err := invoke(r.Context(), h, proto.OrderService_GetOrder_FullMethodName, &proto.GetOrderRequest{OrderId: r.PathValue("id")}, h.orders.GetOrder)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+	req := &proto.UpdateOrderStatusRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	req.OrderId = r.PathValue("id")
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_UpdateOrderStatus_FullMethodName, req, h.orders.UpdateOrderStatus)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	req := &proto.CancelOrderRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	req.OrderId = r.PathValue("id")
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_CancelOrder_FullMethodName, req, h.orders.CancelOrder)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) UpdateShippingAddress(w http.ResponseWriter, r *http.Request) {
+	req := &proto.UpdateShippingAddressRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	req.OrderId = r.PathValue("id")
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_UpdateShippingAddress_FullMethodName, req, h.orders.UpdateShippingAddress)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	req := &proto.AddItemRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	req.OrderId = r.PathValue("id")
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_AddItem_FullMethodName, req, h.orders.AddItem)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+// RemoveItem takes no body; expected_version may be passed as a query
+// parameter instead.
+func (h *OrderHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	req := &proto.RemoveItemRequest{
+		OrderId:   r.PathValue("id"),
+		ProductId: r.PathValue("product_id"),
+	}
+	if v := r.URL.Query().Get("expected_version"); v != "" {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "invalid expected_version"))
+			return
+		}
+		req.ExpectedVersion = &version
+	}
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_RemoveItem_FullMethodName, req, h.orders.RemoveItem)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) ChangeItemQuantity(w http.ResponseWriter, r *http.Request) {
+	req := &proto.ChangeItemQuantityRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	req.OrderId = r.PathValue("id")
+	req.ProductId = r.PathValue("product_id")
+
+	resp, err := invoke(r.Context(), h, proto.OrderService_ChangeItemQuantity_FullMethodName, req, h.orders.ChangeItemQuantity)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp.Order)
+}
+
+func (h *OrderHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	resp, err := invoke(r.Context(), h, proto.OrderService_GetOrderHistory_FullMethodName, &proto.GetOrderHistoryRequest{OrderId: r.PathValue("id")}, h.orders.GetOrderHistory)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, msg protobuf.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if errors.As(err, new(*http.MaxBytesError)) {
+		writeStatus(w, http.StatusRequestEntityTooLarge,
+			status.New(codes.ResourceExhausted, fmt.Sprintf("request body is larger than %d bytes", maxBodyBytes)))
+		return false
+	}
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "failed to read request body"))
+		return false
+	}
+
+	if err := unmarshalOptions.Unmarshal(body, msg); err != nil {
+		writeError(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func writeMessage(w http.ResponseWriter, code int, msg protobuf.Message) {
+	data, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, "internal server error"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+type errorResponse struct {
+	Code            string           `json:"code"`
+	Message         string           `json:"message"`
+	FieldViolations []fieldViolation `json:"field_violations,omitempty"`
+}
+
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// writeError translates a gRPC status error produced by the gRPC handler to
+// the matching HTTP status. Field violations of a BadRequest detail are
+// passed on in the body.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeStatus(w, HTTPStatusFromCode(st.Code()), st)
+}
+
+// writeStatus writes st with the HTTP status code, for errors that have an
+// HTTP status of their own.
+func writeStatus(w http.ResponseWriter, code int, st *status.Status) {
+	response := errorResponse{
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.FieldViolations {
+				response.FieldViolations = append(response.FieldViolations, fieldViolation{Field: v.Field, Description: v.Description})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HTTPStatusFromCode maps gRPC codes to HTTP statuses following
+// https://cloud.google.com/apis/design/errors.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
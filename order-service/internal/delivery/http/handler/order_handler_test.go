@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	grpchandler "order-service/internal/delivery/grpc/handler"
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+	"order-service/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(repo *repositorytest.MockOrderRepository) http.Handler {
+	orders := grpchandler.NewOrderHandler(usecase.NewOrderUseCase(repo))
+	return NewOrderHandler(orders).Routes()
+}
+
+func serve(h http.Handler, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func pendingOrder() *entities.Order {
+	return &entities.Order{
+		OrderID:     "order123",
+		UserID:      "user123",
+		Items:       []entities.Item{{ProductID: "prod1", Quantity: 2, Price: entities.NewMoney(1000, "RUB")}},
+		Currency:    "RUB",
+		TotalAmount: entities.NewMoney(2000, "RUB"),
+		Status:      string(entities.OrderStatusPending),
+		CreatedAt:   time.Now(),
+		Version:     1,
+	}
+}
+
+func TestOrderHandler_CreateOrder(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	repo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(nil, repositories.ErrOrderNotFound)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			order := args.Get(1).(*entities.Order)
+			assert.Equal(t, "key-1", order.IdempotencyKey)
+		})
+
+	body := `{"user_id": "user123", "items": [{"product_id": "prod1", "quantity": 2, "unit_price": {"amount": "1000", "currency": "RUB"}}]}`
+	rec := serve(h, http.MethodPost, "/v1/orders", body, "Idempotency-Key", "key-1")
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "user123", resp["user_id"])
+	assert.Equal(t, "PENDING", resp["status"])
+	assert.Equal(t, map[string]any{"amount": "2000", "currency": "RUB"}, resp["total"])
+	repo.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_InvalidBody(t *testing.T) {
+	h := newTestServer(new(repositorytest.MockOrderRepository))
+
+	rec := serve(h, http.MethodPost, "/v1/orders", `{"user_id": `)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp errorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "InvalidArgument", resp.Code)
+}
+
+func TestOrderHandler_CreateOrder_BodyTooLarge(t *testing.T) {
+	h := newTestServer(new(repositorytest.MockOrderRepository))
+
+	body := `{"user_id": "` + strings.Repeat("x", maxBodyBytes) + `"}`
+	rec := serve(h, http.MethodPost, "/v1/orders", body)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var resp errorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "ResourceExhausted", resp.Code)
+}
+
+func TestOrderHandler_CreateOrder_FieldViolations(t *testing.T) {
+	h := newTestServer(new(repositorytest.MockOrderRepository))
+
+	body := `{"user_id": "user123", "items": [{"product_id": "prod1", "quantity": 1}, {"quantity": 0}]}`
+	rec := serve(h, http.MethodPost, "/v1/orders", body)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp errorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "InvalidArgument", resp.Code)
+	assert.Equal(t, []fieldViolation{
+		{Field: "items[1].product_id", Description: "is required"},
+		{Field: "items[1].quantity", Description: "must be positive"},
+	}, resp.FieldViolations)
+}
+
+func TestOrderHandler_GetOrder(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	repo.On("GetByID", mock.Anything, "order123").Return(pendingOrder(), nil)
+	repo.On("GetByID", mock.Anything, "missing").Return(nil, repositories.ErrOrderNotFound)
+
+	rec := serve(h, http.MethodGet, "/v1/orders/order123", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"order_id":"order123"`)
+
+	rec = serve(h, http.MethodGet, "/v1/orders/missing", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestOrderHandler_UpdateOrderStatus(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	repo.On("GetByID", mock.Anything, "order123").Return(pendingOrder(), nil)
+	repo.On("UpdateStatus", mock.Anything, "order123", repositorytest.ChangeTo("PAID"), int64(1), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+
+	rec := serve(h, http.MethodPatch, "/v1/orders/order123/status", `{"status": "PAID", "expected_version": 1}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"PAID"`)
+
+	rec = serve(h, http.MethodPatch, "/v1/orders/order123/status", `{"status": "PAID", "expected_version": 5}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"Aborted"`)
+	repo.AssertExpectations(t)
+}
+
+func TestOrderHandler_CancelOrder(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	repo.On("GetByID", mock.Anything, "order123").Return(pendingOrder(), nil)
+	repo.On("Cancel", mock.Anything, "order123", mock.AnythingOfType("entities.Cancellation"), int64(1), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+
+	body := `{"reason": "CANCEL_REASON_CUSTOMER_REQUEST", "comment": "changed my mind", "cancelled_by": "user123"}`
+	rec := serve(h, http.MethodPost, "/v1/orders/order123/cancel", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "CANCELLED", resp["status"])
+	cancellation := resp["cancellation"].(map[string]any)
+	assert.Equal(t, "CANCEL_REASON_CUSTOMER_REQUEST", cancellation["reason"])
+	assert.Equal(t, "user123", cancellation["cancelled_by"])
+
+	rec = serve(h, http.MethodPost, "/v1/orders/order123/cancel", `{"cancelled_by": "user123"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestOrderHandler_UpdateShippingAddress(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	repo.On("GetByID", mock.Anything, "order123").Return(pendingOrder(), nil).Once()
+	repo.On("UpdateShipping", mock.Anything, "order123", mock.AnythingOfType("entities.ShippingUpdate"), int64(1), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+
+	body := `{"shipping_address": {"recipient_name": "Anna Schmidt", "line1": "Unter den Linden 1", "city": "Berlin",
+		"postal_code": "10117", "country_code": "de"}, "delivery_method": "DELIVERY_METHOD_POST"}`
+	rec := serve(h, http.MethodPut, "/v1/orders/order123/shipping", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "DELIVERY_METHOD_POST", resp["delivery_method"])
+	address := resp["shipping_address"].(map[string]any)
+	assert.Equal(t, "DE", address["country_code"])
+
+	paid := pendingOrder()
+	paid.Status = "PAID"
+	repo.On("GetByID", mock.Anything, "order123").Return(paid, nil).Once()
+
+	rec = serve(h, http.MethodPut, "/v1/orders/order123/shipping", body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestOrderHandler_EditItems(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	// Every edit reads the order afresh; the use case modifies what it reads.
+	edit := func(method, path, body string) *httptest.ResponseRecorder {
+		repo.On("GetByID", mock.Anything, "order123").Return(pendingOrder(), nil).Once()
+		return serve(h, method, path, body)
+	}
+	repo.On("UpdateItems", mock.Anything, "order123", mock.AnythingOfType("entities.ItemsUpdate"), int64(1), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+
+	rec := edit(http.MethodPost, "/v1/orders/order123/items", `{"item": {"product_id": "prod2", "quantity": 1, "unit_price": {"amount": "500"}}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp["items"], 2)
+	assert.Equal(t, map[string]any{"amount": "2500", "currency": "RUB"}, resp["total"])
+
+	rec = edit(http.MethodPatch, "/v1/orders/order123/items/prod1", `{"quantity": 3}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	resp = nil
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]any{"amount": "3000", "currency": "RUB"}, resp["total"])
+
+	rec = edit(http.MethodDelete, "/v1/orders/order123/items/missing", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = edit(http.MethodDelete, "/v1/orders/order123/items/prod1?expected_version=5", "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// The last line of an order cannot be removed.
+	rec = edit(http.MethodDelete, "/v1/orders/order123/items/prod1", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(h, http.MethodDelete, "/v1/orders/order123/items/prod1?expected_version=latest", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "UpdateItems", 2)
+}
+
+func TestOrderHandler_GetOrderHistory(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	h := newTestServer(repo)
+
+	changedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo.On("GetHistory", mock.Anything, "order123").Return([]entities.StatusChange{
+		{To: "PENDING", ChangedAt: changedAt, Actor: "user123", Version: 1},
+		{From: "PENDING", To: "PAID", ChangedAt: changedAt.Add(time.Minute), Actor: "payment-service", Version: 2},
+	}, nil)
+
+	rec := serve(h, http.MethodGet, "/v1/orders/order123/history", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Changes []map[string]any `json:"changes"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Changes, 2)
+	assert.Equal(t, "PAID", resp.Changes[1]["to_status"])
+	assert.Equal(t, "payment-service", resp.Changes[1]["actor"])
+	assert.Equal(t, "2025-03-01T12:01:00Z", resp.Changes[1]["changed_at"])
+	repo.AssertExpectations(t)
+}
+
+func TestOrderHandler_MethodNotAllowed(t *testing.T) {
+	h := newTestServer(new(repositorytest.MockOrderRepository))
+
+	rec := serve(h, http.MethodDelete, "/v1/orders/order123", "")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestOrderHandler_Interceptors(t *testing.T) {
+	repo := new(repositorytest.MockOrderRepository)
+	repo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(pendingOrder(), nil)
+	repo.On("GetByID", mock.Anything, "missing").Return(nil, repositories.ErrOrderNotFound)
+
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			resp, err := handler(ctx, req)
+			md, _ := metadata.FromIncomingContext(ctx)
+			calls = append(calls, name+" "+info.FullMethod+" "+status.Code(err).String()+" "+strings.Join(md.Get("x-request-id"), ","))
+			return resp, err
+		}
+	}
+	orders := grpchandler.NewOrderHandler(usecase.NewOrderUseCase(repo))
+	h := NewOrderHandler(orders, record("outer"), record("inner")).Routes()
+
+	// Metadata set before the handler, such as the request ID, is kept
+	// alongside the idempotency key, which is taken by an order made from
+	// another request.
+	body := `{"user_id": "user123", "items": [{"product_id": "prod1", "quantity": 2, "unit_price": {"amount": "1000", "currency": "RUB"}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "key-1")
+	req = req.WithContext(metadata.NewIncomingContext(req.Context(), metadata.Pairs("x-request-id", "req-1")))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serve(h, http.MethodGet, "/v1/orders/missing", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Equal(t, []string{
+		"inner /order.OrderService/CreateOrder AlreadyExists req-1",
+		"outer /order.OrderService/CreateOrder AlreadyExists req-1",
+		"inner /order.OrderService/GetOrder NotFound ",
+		"outer /order.OrderService/GetOrder NotFound ",
+	}, calls)
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.Aborted:            http.StatusConflict,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.Unknown:            http.StatusInternalServerError,
+	}
+
+	for code, want := range tests {
+		assert.Equal(t, want, HTTPStatusFromCode(code), code.String())
+	}
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "order.created"
+	OrderEventStatusChanged OrderEventType = "order.status_changed"
+	OrderEventCancelled     OrderEventType = "order.cancelled"
+	// OrderEventShippingUpdated announces new shipping details of a pending order.
+	OrderEventShippingUpdated OrderEventType = "order.shipping_updated"
+	// OrderEventUpdated announces new items and amounts of a pending order.
+	OrderEventUpdated OrderEventType = "order.updated"
+)
+
+// OrderEvent is a change to an order that must be announced to other services.
+// It is stored together with the change itself and delivered asynchronously.
+// Order is a snapshot taken after the change; PreviousStatus is set for status
+// changes and cancellations.
+// TraceContext holds the W3C trace context of the request that caused the change,
+// so that delivering the event can be linked back to it.
+type OrderEvent struct {
+	EventID        string            `json:"event_id"`
+	Type           OrderEventType    `json:"type"`
+	Order          *Order            `json:"order"`
+	PreviousStatus string            `json:"previous_status,omitempty"`
+	OccurredAt     time.Time         `json:"occurred_at"`
+	TraceContext   map[string]string `json:"trace_context,omitempty"`
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultCurrency is used for amounts that arrive without a currency, such as
+// float prices from older clients and documents stored before Money existed.
+const DefaultCurrency = "RUB"
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrMoneyOverflow    = errors.New("money amount overflow")
+)
+
+// currencyExponents holds the number of minor units digits for currencies that
+// do not use the common two (ISO 4217).
+var currencyExponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// Money is an exact monetary amount in the minor units of an ISO 4217 currency,
+// e.g. kopecks for RUB or cents for USD.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// MoneyFromFloat converts an amount in major units, rounding half away from zero
+// to the nearest minor unit.
+func MoneyFromFloat(amount float64, currency string) (Money, error) {
+	minor := math.Round(amount * math.Pow10(CurrencyExponent(currency)))
+	if math.IsNaN(minor) || minor > math.MaxInt64 || minor < math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: int64(minor), Currency: currency}, nil
+}
+
+// ValidCurrencyCode reports whether code looks like an ISO 4217 code: three
+// upper-case Latin letters.
+func ValidCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyExponent returns the number of minor unit digits of currency.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Float returns the amount in major units. It is meant for display and for
+// deprecated API fields only, never for arithmetic.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(CurrencyExponent(m.Currency))
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+func (m Money) Mul(n int64) (Money, error) {
+	if n != 0 && (m.Amount*n)/n != m.Amount {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: m.Amount * n, Currency: m.Currency}, nil
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) String() string {
+	exp := CurrencyExponent(m.Currency)
+	if exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	unit := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/unit, exp, amount%unit, m.Currency)
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{amount: 10.0, currency: "RUB", want: 1000},
+		{amount: 0.1 + 0.2, currency: "USD", want: 30},
+		{amount: 19.999, currency: "EUR", want: 2000},
+		{amount: 1.005, currency: "USD", want: 100},
+		{amount: 1500, currency: "JPY", want: 1500},
+		{amount: 1.2345, currency: "KWD", want: 1235},
+	}
+
+	for _, tt := range tests {
+		got, err := MoneyFromFloat(tt.amount, tt.currency)
+		assert.NoError(t, err)
+		assert.Equal(t, NewMoney(tt.want, tt.currency), got, "%v %s", tt.amount, tt.currency)
+	}
+
+	_, err := MoneyFromFloat(math.Inf(1), "RUB")
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := NewMoney(1999, "RUB")
+
+	line, err := price.Mul(3)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(5997, "RUB"), line)
+
+	total, err := line.Add(NewMoney(3, "RUB"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(6000, "RUB"), total)
+
+	_, err = total.Add(NewMoney(100, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney(math.MaxInt64/2+1, "RUB").Mul(2)
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+
+	_, err = NewMoney(math.MaxInt64, "RUB").Add(NewMoney(1, "RUB"))
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "25.00 RUB", NewMoney(2500, "RUB").String())
+	assert.Equal(t, "-0.05 USD", NewMoney(-5, "USD").String())
+	assert.Equal(t, "1500 JPY", NewMoney(1500, "JPY").String())
+}
@@ -9,6 +9,7 @@ const (
 	OrderStatusPaid      OrderStatus = "PAID"
 	OrderStatusCancelled OrderStatus = "CANCELLED"
 	OrderStatusFailed    OrderStatus = "FAILED"
+	OrderStatusRefunded  OrderStatus = "REFUNDED"
 )
 
 var validStatuses = map[OrderStatus]bool{
@@ -16,23 +17,186 @@ var validStatuses = map[OrderStatus]bool{
 	OrderStatusPaid:      true,
 	OrderStatusCancelled: true,
 	OrderStatusFailed:    true,
+	OrderStatusRefunded:  true,
 }
 
+// statusTransitions lists the statuses an order may move to from a given status.
+// Statuses without an entry are terminal.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusPaid:    {OrderStatusRefunded},
+}
+
+// Order is a customer order. IdempotencyKey is the client-supplied key the order
+// was created with, if any; RequestHash fingerprints that create request so a
+// replay with a different payload under the same key can be detected.
+//
+// Subtotal is the sum of the items; TotalAmount, what the customer pays, is
+// Subtotal less the Discounts given by the promotion behind PromoCode plus
+// the exclusive part of TaxAmount. TaxAmount is the tax levied in Region on
+// all items, both included in their prices and added on top.
+//
+// Items, and with them the amounts, ShippingAddress and DeliveryMethod may
+// only change while the order is pending. ShippingAddress and DeliveryMethod
+// are empty on orders placed before they were introduced; ShippingAddress is
+// also nil for orders that are picked up.
 type Order struct {
-	OrderID     string    `json:"order_id"`
-	UserID      string    `json:"user_id"`
-	Items       []Item    `json:"items"`
-	TotalAmount float64   `json:"total_amount"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	OrderID         string           `json:"order_id"`
+	UserID          string           `json:"user_id"`
+	Items           []Item           `json:"items"`
+	Currency        string           `json:"currency"`
+	Subtotal        Money            `json:"subtotal"`
+	PromoCode       string           `json:"promo_code,omitempty"`
+	Discounts       []Discount       `json:"discounts,omitempty"`
+	Region          string           `json:"region,omitempty"`
+	TaxAmount       Money            `json:"tax_amount"`
+	TotalAmount     Money            `json:"total_amount"`
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+	DeliveryMethod  DeliveryMethod   `json:"delivery_method,omitempty"`
+	Status          string           `json:"status"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	Version         int64            `json:"version"`
+	IdempotencyKey  string           `json:"idempotency_key,omitempty"`
+	RequestHash     string           `json:"-"`
+	// Cancellation is set once the order has been cancelled with a reason.
+	Cancellation *Cancellation `json:"cancellation,omitempty"`
+}
+
+type CancelReason string
+
+const (
+	CancelReasonCustomerRequest CancelReason = "CUSTOMER_REQUEST"
+	CancelReasonOutOfStock      CancelReason = "OUT_OF_STOCK"
+	CancelReasonPaymentTimeout  CancelReason = "PAYMENT_TIMEOUT"
+	CancelReasonFraudSuspected  CancelReason = "FRAUD_SUSPECTED"
+	CancelReasonOther           CancelReason = "OTHER"
+)
+
+// Cancellation records who cancelled an order, when and why.
+type Cancellation struct {
+	CancelledAt time.Time    `json:"cancelled_at"`
+	Reason      CancelReason `json:"reason"`
+	Comment     string       `json:"comment,omitempty"`
+	CancelledBy string       `json:"cancelled_by"`
+}
+
+// StatusChange is one entry of an order's status history. From is empty for
+// the entry recorded when the order is created. Version is the order version
+// the change produced.
+type StatusChange struct {
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	ChangedAt time.Time `json:"changed_at"`
+	Actor     string    `json:"actor,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Version   int64     `json:"version"`
+}
+
+// CreationChange returns the history entry recording that the order's user
+// created it.
+func (o *Order) CreationChange() StatusChange {
+	return StatusChange{
+		To:        o.Status,
+		ChangedAt: o.CreatedAt,
+		Actor:     o.UserID,
+		Version:   o.Version,
+	}
+}
+
+// StatusChange returns the history entry recording the cancellation of an order
+// that was in status from, producing version.
+func (c Cancellation) StatusChange(from string, version int64) StatusChange {
+	return StatusChange{
+		From:      from,
+		To:        string(OrderStatusCancelled),
+		ChangedAt: c.CancelledAt,
+		Actor:     c.CancelledBy,
+		Reason:    string(c.Reason),
+		Version:   version,
+	}
 }
 
+// Item is an order line. Category selects the tax rule for the product; Tax
+// is nil on orders placed without tax calculation.
 type Item struct {
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ProductID string   `json:"product_id"`
+	Quantity  int      `json:"quantity"`
+	Price     Money    `json:"price"`
+	Category  string   `json:"category,omitempty"`
+	Tax       *ItemTax `json:"tax,omitempty"`
+}
+
+// ItemsUpdate replaces the items of an order together with the amounts worked
+// out for them.
+type ItemsUpdate struct {
+	Items       []Item
+	Subtotal    Money
+	Discounts   []Discount
+	TaxAmount   Money
+	TotalAmount Money
+	UpdatedAt   time.Time
+}
+
+func ValidCancelReason(reason CancelReason) bool {
+	switch reason {
+	case CancelReasonCustomerRequest, CancelReasonOutOfStock, CancelReasonPaymentTimeout,
+		CancelReasonFraudSuspected, CancelReasonOther:
+		return true
+	}
+	return false
 }
 
 func ValidStatus(status string) bool {
 	return validStatuses[OrderStatus(status)]
 }
+
+// CanTransitionTo reports whether an order in status s may be moved to next.
+// Setting the status an order already has is allowed and treated as a no-op.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	if !validStatuses[s] || !validStatuses[next] {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Cancellable reports whether an order in status s may be cancelled. Unlike
+// CanTransitionTo, cancelling an already cancelled order is not a no-op.
+func (s OrderStatus) Cancellable() bool {
+	return s != OrderStatusCancelled && s.CanTransitionTo(OrderStatusCancelled)
+}
+
+func CanTransition(from, to string) bool {
+	return OrderStatus(from).CanTransitionTo(OrderStatus(to))
+}
+
+// SourceStatuses returns every status from which an order may be moved to status,
+// including status itself.
+func SourceStatuses(status string) []string {
+	var sources []string
+	for s := range validStatuses {
+		if s.CanTransitionTo(OrderStatus(status)) {
+			sources = append(sources, string(s))
+		}
+	}
+	return sources
+}
+
+// CancellableStatuses returns every status an order may be cancelled from.
+func CancellableStatuses() []string {
+	var statuses []string
+	for s := range validStatuses {
+		if s.Cancellable() {
+			statuses = append(statuses, string(s))
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,10 @@
+package entities
+
+// Product is the catalog's view of something that can be ordered: its current
+// unit price, whether it can be ordered right now and its tax category.
+type Product struct {
+	ProductID string
+	Price     Money
+	Available bool
+	Category  string
+}
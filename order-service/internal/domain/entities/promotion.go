@@ -0,0 +1,139 @@
+package entities
+
+import "fmt"
+
+// Discount is a line of an order's discount breakdown: the amount one rule of
+// the promotion behind PromoCode took off the order.
+type Discount struct {
+	PromoCode   string `json:"promo_code"`
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"`
+}
+
+// DiscountRule is one way a promotion lowers the price of an order.
+type DiscountRule interface {
+	// Discount returns the amount the rule takes off an order of items whose
+	// prices add up to subtotal, and false if the rule does not apply to it.
+	Discount(items []Item, subtotal Money) (Money, bool)
+	// Description explains the rule to the customer.
+	Description() string
+}
+
+// Promotion is what a promo code gives: the discounts of all of its rules that
+// apply to the order.
+type Promotion struct {
+	Code  string
+	Rules []DiscountRule
+}
+
+// Apply returns a discount line for every rule of the promotion that applies
+// to items. The lines never add up to more than subtotal: the last one is cut
+// down if they would.
+func (p *Promotion) Apply(items []Item, subtotal Money) []Discount {
+	var discounts []Discount
+	remaining := subtotal.Amount
+	for _, rule := range p.Rules {
+		if remaining == 0 {
+			break
+		}
+		amount, ok := rule.Discount(items, subtotal)
+		if !ok || amount.Amount <= 0 {
+			continue
+		}
+		amount.Amount = min(amount.Amount, remaining)
+		remaining -= amount.Amount
+
+		discounts = append(discounts, Discount{
+			PromoCode:   p.Code,
+			Description: rule.Description(),
+			Amount:      amount,
+		})
+	}
+	return discounts
+}
+
+// PercentageOff takes Percent percent off the subtotal, rounded down to the
+// minor unit.
+type PercentageOff struct {
+	Percent int64
+}
+
+func (r PercentageOff) Discount(_ []Item, subtotal Money) (Money, bool) {
+	// Split the multiplication so that it cannot overflow for any subtotal.
+	amount := subtotal.Amount/100*r.Percent + subtotal.Amount%100*r.Percent/100
+	return NewMoney(amount, subtotal.Currency), true
+}
+
+func (r PercentageOff) Description() string {
+	return fmt.Sprintf("%d%% off", r.Percent)
+}
+
+// FixedAmountOff takes Amount off orders placed in the currency of Amount.
+type FixedAmountOff struct {
+	Amount Money
+}
+
+func (r FixedAmountOff) Discount(_ []Item, subtotal Money) (Money, bool) {
+	return r.Amount, r.Amount.Currency == subtotal.Currency
+}
+
+func (r FixedAmountOff) Description() string {
+	return fmt.Sprintf("%s off", r.Amount)
+}
+
+// BuyXGetY gives Get units of ProductID for free with every Buy units bought:
+// out of every Buy+Get units ordered, Get are free. When the product is priced
+// differently on several lines, the cheapest price is taken off.
+type BuyXGetY struct {
+	ProductID string
+	Buy       int
+	Get       int
+}
+
+func (r BuyXGetY) Discount(items []Item, _ Money) (Money, bool) {
+	if r.Buy <= 0 || r.Get <= 0 {
+		return Money{}, false
+	}
+
+	quantity := 0
+	var price Money
+	for _, item := range items {
+		if item.ProductID != r.ProductID {
+			continue
+		}
+		if quantity == 0 || item.Price.Amount < price.Amount {
+			price = item.Price
+		}
+		quantity += item.Quantity
+	}
+
+	free := quantity / (r.Buy + r.Get) * r.Get
+	if free == 0 {
+		return Money{}, false
+	}
+	// Never more than the lines of the product, which fit into subtotal.
+	amount, err := price.Mul(int64(free))
+	return amount, err == nil
+}
+
+func (r BuyXGetY) Description() string {
+	return fmt.Sprintf("buy %d %s, get %d free", r.Buy, r.ProductID, r.Get)
+}
+
+// MinBasket applies Rule only to orders whose subtotal is at least Min, in the
+// currency of Min.
+type MinBasket struct {
+	Min  Money
+	Rule DiscountRule
+}
+
+func (r MinBasket) Discount(items []Item, subtotal Money) (Money, bool) {
+	if subtotal.Currency != r.Min.Currency || subtotal.Amount < r.Min.Amount {
+		return Money{}, false
+	}
+	return r.Rule.Discount(items, subtotal)
+}
+
+func (r MinBasket) Description() string {
+	return fmt.Sprintf("%s on orders from %s", r.Rule.Description(), r.Min)
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountRules(t *testing.T) {
+	rub := func(kopecks int64) Money { return NewMoney(kopecks, "RUB") }
+	items := []Item{
+		{ProductID: "prod1", Quantity: 4, Price: rub(1000)},
+		{ProductID: "prod2", Quantity: 1, Price: rub(999)},
+		{ProductID: "prod1", Quantity: 1, Price: rub(900)},
+	}
+	subtotal := rub(5899)
+
+	tests := []struct {
+		name   string
+		rule   DiscountRule
+		want   Money
+		wantOK bool
+	}{
+		{name: "percentage rounds down", rule: PercentageOff{Percent: 15}, want: rub(884), wantOK: true},
+		{name: "fixed amount", rule: FixedAmountOff{Amount: rub(500)}, want: rub(500), wantOK: true},
+		{name: "fixed amount in another currency", rule: FixedAmountOff{Amount: NewMoney(500, "EUR")}},
+		{name: "buy 2 get 1 at the cheapest price", rule: BuyXGetY{ProductID: "prod1", Buy: 2, Get: 1}, want: rub(900), wantOK: true},
+		{name: "buy x get y below quantity", rule: BuyXGetY{ProductID: "prod2", Buy: 1, Get: 1}},
+		{name: "min basket reached", rule: MinBasket{Min: rub(5000), Rule: PercentageOff{Percent: 10}}, want: rub(589), wantOK: true},
+		{name: "min basket not reached", rule: MinBasket{Min: rub(6000), Rule: PercentageOff{Percent: 10}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.Discount(items, subtotal)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPercentageOff_LargeSubtotal(t *testing.T) {
+	subtotal := NewMoney(9_000_000_000_000_000_000, "RUB")
+
+	got, ok := PercentageOff{Percent: 50}.Discount(nil, subtotal)
+
+	assert.True(t, ok)
+	assert.Equal(t, NewMoney(4_500_000_000_000_000_000, "RUB"), got)
+}
+
+func TestPromotion_Apply(t *testing.T) {
+	rub := func(kopecks int64) Money { return NewMoney(kopecks, "RUB") }
+	items := []Item{{ProductID: "prod1", Quantity: 3, Price: rub(1000)}}
+
+	promotion := &Promotion{Code: "SPRING", Rules: []DiscountRule{
+		BuyXGetY{ProductID: "prod1", Buy: 2, Get: 1},
+		MinBasket{Min: rub(10000), Rule: PercentageOff{Percent: 50}},
+		FixedAmountOff{Amount: rub(2500)},
+	}}
+
+	discounts := promotion.Apply(items, rub(3000))
+
+	// The fixed amount is cut down so that the order is not paid for below zero.
+	assert.Equal(t, []Discount{
+		{PromoCode: "SPRING", Description: "buy 2 prod1, get 1 free", Amount: rub(1000)},
+		{PromoCode: "SPRING", Description: "25.00 RUB off", Amount: rub(2000)},
+	}, discounts)
+}
@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+// DeliveryMethod is how an order reaches the customer.
+type DeliveryMethod string
+
+const (
+	DeliveryMethodCourier DeliveryMethod = "COURIER"
+	DeliveryMethodPost    DeliveryMethod = "POST"
+	// DeliveryMethodPickup orders are collected by the customer and have no
+	// shipping address.
+	DeliveryMethodPickup DeliveryMethod = "PICKUP"
+)
+
+func ValidDeliveryMethod(method DeliveryMethod) bool {
+	switch method {
+	case DeliveryMethodCourier, DeliveryMethodPost, DeliveryMethodPickup:
+		return true
+	}
+	return false
+}
+
+// RequiresAddress reports whether orders delivered by m must have a shipping
+// address.
+func (m DeliveryMethod) RequiresAddress() bool {
+	return m == DeliveryMethodCourier || m == DeliveryMethodPost
+}
+
+// ShippingAddress is where an order is delivered. CountryCode is an ISO 3166-1
+// alpha-2 code such as "RU"; State is the state, province or oblast, where the
+// country has them.
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone,omitempty"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	State         string `json:"state,omitempty"`
+	PostalCode    string `json:"postal_code"`
+	CountryCode   string `json:"country_code"`
+}
+
+// ShippingUpdate replaces the shipping details of an order. Address is nil for
+// orders that are picked up.
+type ShippingUpdate struct {
+	Address        *ShippingAddress
+	DeliveryMethod DeliveryMethod
+	UpdatedAt      time.Time
+}
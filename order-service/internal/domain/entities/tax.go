@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrNoTaxRule is returned when no tax rule covers a product category in a region.
+var ErrNoTaxRule = errors.New("no tax rule")
+
+// basisPointsPerUnit is 100%, in basis points.
+const basisPointsPerUnit = 10000
+
+// TaxRule is the tax levied on products of Category sold in Region. "*" in
+// either field matches any value.
+type TaxRule struct {
+	Region   string
+	Category string
+	// RateBasisPoints is the rate in hundredths of a percent: 2000 is 20%.
+	RateBasisPoints int64
+	// Inclusive rules treat prices as including the tax, exclusive ones add
+	// the tax on top of them.
+	Inclusive bool
+}
+
+// ItemTax is the tax on an order line, after the line's share of discounts.
+type ItemTax struct {
+	RateBasisPoints int64 `json:"rate_basis_points"`
+	Inclusive       bool  `json:"inclusive"`
+	Amount          Money `json:"amount"`
+}
+
+// TaxableLine is what tax is levied on: an order line's amount, after its
+// share of discounts, and the category of its product.
+type TaxableLine struct {
+	Category string
+	Amount   Money
+}
+
+// Tax returns the tax the rule levies on amount, rounded half away from zero to
+// the minor unit: amount × rate when exclusive, amount × rate / (1 + rate) when
+// amount already includes the tax.
+func (r TaxRule) Tax(amount Money) ItemTax {
+	divisor := int64(basisPointsPerUnit)
+	if r.Inclusive {
+		divisor += r.RateBasisPoints
+	}
+
+	return ItemTax{
+		RateBasisPoints: r.RateBasisPoints,
+		Inclusive:       r.Inclusive,
+		Amount:          NewMoney(mulDivRound(amount.Amount, r.RateBasisPoints, divisor), amount.Currency),
+	}
+}
+
+// AllocateDiscount spreads discount over lines in proportion to their amounts
+// and returns what remains of each line. Shares are rounded down; the minor
+// units left over go one each to the first lines that can take them, so the
+// shares always add up to discount exactly. discount must not exceed the sum of
+// lines.
+func AllocateDiscount(lines []Money, discount Money) []Money {
+	discounted := make([]Money, len(lines))
+	copy(discounted, lines)
+	if discount.Amount <= 0 {
+		return discounted
+	}
+
+	var total int64
+	for _, line := range lines {
+		total += line.Amount
+	}
+	if total == 0 {
+		return discounted
+	}
+
+	leftover := discount.Amount
+	for i, line := range lines {
+		share := mulDiv(discount.Amount, line.Amount, total)
+		discounted[i].Amount -= share
+		leftover -= share
+	}
+	for i := range discounted {
+		if leftover == 0 {
+			break
+		}
+		if discounted[i].Amount > 0 {
+			discounted[i].Amount--
+			leftover--
+		}
+	}
+	return discounted
+}
+
+// mulDiv returns a × b / c rounded towards zero, without overflowing on the way.
+func mulDiv(a, b, c int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	return product.Quo(product, big.NewInt(c)).Int64()
+}
+
+// mulDivRound returns a × b / c rounded half away from zero, without
+// overflowing on the way. c must be positive.
+func mulDivRound(a, b, c int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(c), new(big.Int))
+
+	// Round away from zero when the remainder is at least half of c.
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(big.NewInt(c)) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient.Int64()
+}
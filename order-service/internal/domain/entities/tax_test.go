@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxRule_Tax(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      int64
+		inclusive bool
+		amount    int64
+		want      int64
+	}{
+		{name: "exclusive 20%", rate: 2000, amount: 1000, want: 200},
+		{name: "exclusive rounds down below half", rate: 2000, amount: 1, want: 0},
+		{name: "exclusive rounds up above half", rate: 2000, amount: 3, want: 1},
+		{name: "exclusive rounds half up", rate: 1000, amount: 5, want: 1},
+		{name: "exclusive rounds half up again", rate: 1000, amount: 15, want: 2},
+		{name: "exclusive 7.25%", rate: 725, amount: 1999, want: 145},
+		{name: "exclusive 7.25% half", rate: 725, amount: 200, want: 15},
+		{name: "exclusive 100%", rate: 10000, amount: 999, want: 999},
+		{name: "exclusive zero rate", rate: 0, amount: 999, want: 0},
+		{name: "negative rounds half away from zero", rate: 1000, amount: -5, want: -1},
+		{name: "inclusive 20%", rate: 2000, inclusive: true, amount: 1200, want: 200},
+		{name: "inclusive rounds half up", rate: 2000, inclusive: true, amount: 999, want: 167},
+		{name: "inclusive 10%", rate: 1000, inclusive: true, amount: 1100, want: 100},
+		{name: "inclusive rounds down below half", rate: 1000, inclusive: true, amount: 1, want: 0},
+		{name: "inclusive 7.25%", rate: 725, inclusive: true, amount: 10725, want: 725},
+		{name: "inclusive zero rate", rate: 0, inclusive: true, amount: 999, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := TaxRule{Region: "RU", Category: "*", RateBasisPoints: tt.rate, Inclusive: tt.inclusive}
+			assert.Equal(t, ItemTax{
+				RateBasisPoints: tt.rate,
+				Inclusive:       tt.inclusive,
+				Amount:          NewMoney(tt.want, "RUB"),
+			}, rule.Tax(NewMoney(tt.amount, "RUB")))
+		})
+	}
+}
+
+func TestAllocateDiscount(t *testing.T) {
+	rub := func(amounts ...int64) []Money {
+		m := make([]Money, len(amounts))
+		for i, a := range amounts {
+			m[i] = NewMoney(a, "RUB")
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		lines    []Money
+		discount int64
+		want     []Money
+	}{
+		{name: "no discount", lines: rub(1000, 500), discount: 0, want: rub(1000, 500)},
+		{name: "even split", lines: rub(1000, 500), discount: 300, want: rub(800, 400)},
+		{name: "leftover goes to the first line", lines: rub(1000, 999, 1), discount: 100, want: rub(949, 950, 1)},
+		{name: "leftover skips empty lines", lines: rub(0, 300, 300), discount: 1, want: rub(0, 299, 300)},
+		{name: "whole subtotal", lines: rub(333, 667), discount: 1000, want: rub(0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := append([]Money(nil), tt.lines...)
+			assert.Equal(t, tt.want, AllocateDiscount(lines, NewMoney(tt.discount, "RUB")))
+			assert.Equal(t, tt.lines, lines, "lines must be left untouched")
+		})
+	}
+}
@@ -3,17 +3,71 @@ package repositories
 import (
 	"context"
 	"order-service/internal/domain/entities"
+	"time"
 )
 
 type OrderRepository interface {
-	Create(ctx context.Context, order *entities.Order) error
+	// Create stores the order and, atomically with it, the event announcing it.
+	Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) error
 	GetByID(ctx context.Context, orderID string) (*entities.Order, error)
-	UpdateStatus(ctx context.Context, orderID, status string) error
+	// GetByIdempotencyKey returns the order the user created with key, or ErrOrderNotFound.
+	GetByIdempotencyKey(ctx context.Context, userID, key string) (*entities.Order, error)
+	// UpdateStatus sets the order status to change.To, its UpdatedAt to
+	// change.ChangedAt and increments its version, provided the stored version
+	// still equals expectedVersion, and appends change to the order's status
+	// history. A non-nil event is stored atomically with the change. Setting
+	// the status the order already has changes nothing, not even the version.
+	UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) error
+	// Cancel moves the order to CANCELLED, stores cancellation with it, sets
+	// UpdatedAt to the cancellation time and increments its version, provided the stored version still equals
+	// expectedVersion. It returns ErrInvalidTransition unless the order is
+	// cancellable. The cancellation is appended to the status history, and
+	// event is stored atomically with the change.
+	Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) error
+	// UpdateShipping replaces the order's shipping address and delivery method
+	// with update's, sets UpdatedAt to update.UpdatedAt and increments its
+	// version, provided the stored version still equals expectedVersion. Every
+	// status change increments the version too, so the order is still in the
+	// status it had at that version. event is stored atomically with the change.
+	UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) error
+	// UpdateItems replaces the order's items, subtotal, discounts, tax and
+	// total with update's, sets UpdatedAt to update.UpdatedAt and increments
+	// its version, provided the stored version still equals expectedVersion.
+	// event is stored atomically with the change.
+	UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) error
+	List(ctx context.Context, filter OrderFilter) ([]*entities.Order, error)
+	// GetHistory returns the status changes of the order, oldest first. Create
+	// records the first one. Entries are never modified or removed.
+	GetHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error)
+}
+
+// OrderFilter selects orders for List. Zero-valued fields are not applied.
+// Results are ordered by CreatedAt descending, then OrderID descending.
+type OrderFilter struct {
+	UserID      string
+	Statuses    []string
+	CreatedFrom time.Time // inclusive
+	CreatedTo   time.Time // exclusive
+	// After, when set, restricts results to orders that sort strictly after the cursor.
+	After *OrderCursor
+	Limit int
+}
+
+// OrderCursor identifies a position in the List ordering.
+type OrderCursor struct {
+	CreatedAt time.Time
+	OrderID   string
 }
 
 var (
 	ErrOrderNotFound      = &RepositoryError{"order not found"}
 	ErrOrderAlreadyExists = &RepositoryError{"order already exists"}
+	// ErrInvalidTransition is returned by UpdateStatus and Cancel when the stored
+	// order's current status does not allow moving to the requested one.
+	ErrInvalidTransition = &RepositoryError{"invalid order status transition"}
+	// ErrVersionConflict is returned by UpdateStatus, Cancel, UpdateShipping and
+	// UpdateItems when the order was modified since expectedVersion was read.
+	ErrVersionConflict = &RepositoryError{"order version conflict"}
 )
 
 type RepositoryError struct {
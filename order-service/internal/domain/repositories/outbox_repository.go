@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"order-service/internal/domain/entities"
+	"time"
+)
+
+// OutboxRepository gives the event relay access to events recorded by
+// OrderRepository in the same transaction as the order change.
+type OutboxRepository interface {
+	// FetchPending returns up to limit undelivered events that are due for a
+	// delivery attempt, oldest first.
+	FetchPending(ctx context.Context, limit int) ([]*OutboxRecord, error)
+	MarkDelivered(ctx context.Context, eventID string) error
+	// MarkFailed records a failed delivery attempt and postpones the next one
+	// until nextAttemptAt.
+	MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) error
+}
+
+// OrderStore is what a storage backend provides: orders together with the
+// outbox of events recorded alongside them.
+type OrderStore interface {
+	OrderRepository
+	OutboxRepository
+}
+
+type OutboxRecord struct {
+	Event    *entities.OrderEvent
+	Attempts int
+}
@@ -0,0 +1,78 @@
+package repositorytest
+
+import (
+	"context"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderRepository is a testify mock of repositories.OrderRepository for
+// tests of the layers above the stores.
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) error {
+	args := m.Called(ctx, order, event)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByIdempotencyKey(ctx context.Context, userID, key string) (*entities.Order, error) {
+	args := m.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) error {
+	args := m.Called(ctx, orderID, change, expectedVersion, event)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) error {
+	args := m.Called(ctx, orderID, cancellation, expectedVersion, event)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	args := m.Called(ctx, orderID, update, expectedVersion, event)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	args := m.Called(ctx, orderID, update, expectedVersion, event)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.StatusChange), args.Error(1)
+}
+
+func (m *MockOrderRepository) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+// ChangeTo matches a status change to status.
+func ChangeTo(status string) interface{} {
+	return mock.MatchedBy(func(change entities.StatusChange) bool { return change.To == status })
+}
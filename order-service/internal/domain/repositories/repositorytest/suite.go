@@ -0,0 +1,841 @@
+// Package repositorytest holds the conformance suite every storage backend
+// must pass, so that the backends stay interchangeable, and a mock repository
+// for testing the layers above them.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewStore returns an empty store for a single test.
+type NewStore func(t *testing.T) repositories.OrderStore
+
+// Run runs the conformance suite against the stores returned by newStore.
+func Run(t *testing.T, newStore NewStore) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store repositories.OrderStore)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateWithDiscounts", testCreateWithDiscounts},
+		{"CreateWithTax", testCreateWithTax},
+		{"CreateDuplicateID", testCreateDuplicateID},
+		{"IdempotencyKey", testIdempotencyKey},
+		{"GetMissing", testGetMissing},
+		{"UpdateStatus", testUpdateStatus},
+		{"UpdateStatusConflicts", testUpdateStatusConflicts},
+		{"Cancel", testCancel},
+		{"CancelConflicts", testCancelConflicts},
+		{"UpdateShipping", testUpdateShipping},
+		{"UpdateShippingConflicts", testUpdateShippingConflicts},
+		{"UpdateItems", testUpdateItems},
+		{"UpdateItemsConflicts", testUpdateItemsConflicts},
+		{"History", testHistory},
+		{"ListFilters", testListFilters},
+		{"ListPagination", testListPagination},
+		{"Outbox", testOutbox},
+		{"OutboxRetry", testOutboxRetry},
+		{"OutboxAtomicity", testOutboxAtomicity},
+		{"ReturnedOrdersAreCopies", testReturnedOrdersAreCopies},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+// baseTime is truncated to milliseconds, the precision every backend keeps.
+var baseTime = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newOrder(userID string, createdAt time.Time) *entities.Order {
+	return &entities.Order{
+		OrderID: uuid.New().String(),
+		UserID:  userID,
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 2, Price: entities.NewMoney(1050, "EUR")},
+			{ProductID: "prod2", Quantity: 1, Price: entities.NewMoney(299, "EUR")},
+		},
+		Currency:    "EUR",
+		Subtotal:    entities.NewMoney(2399, "EUR"),
+		TaxAmount:   entities.NewMoney(0, "EUR"),
+		TotalAmount: entities.NewMoney(2399, "EUR"),
+		Status:      string(entities.OrderStatusPending),
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		Version:     1,
+	}
+}
+
+// statusChange returns a change from one status to another applied to an order
+// at expectedVersion.
+func statusChange(from, to entities.OrderStatus, expectedVersion int64) entities.StatusChange {
+	return entities.StatusChange{
+		From:      string(from),
+		To:        string(to),
+		ChangedAt: baseTime.Add(time.Duration(expectedVersion) * time.Hour),
+		Actor:     "support:alice",
+		Reason:    "requested by customer",
+		Version:   expectedVersion + 1,
+	}
+}
+
+func newEvent(eventType entities.OrderEventType, order *entities.Order, occurredAt time.Time) *entities.OrderEvent {
+	snapshot := *order
+	return &entities.OrderEvent{
+		EventID:    uuid.New().String(),
+		Type:       eventType,
+		Order:      &snapshot,
+		OccurredAt: occurredAt,
+	}
+}
+
+func create(t *testing.T, store repositories.OrderStore, order *entities.Order) *entities.OrderEvent {
+	t.Helper()
+	event := newEvent(entities.OrderEventCreated, order, order.CreatedAt)
+	require.NoError(t, store.Create(context.Background(), order, event))
+	return event
+}
+
+// assertSameOrder compares orders field by field, comparing times by instant
+// since backends may return them in another location.
+func assertSameOrder(t *testing.T, want, got *entities.Order) {
+	t.Helper()
+	require.NotNil(t, got)
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt), "created_at: want %v, got %v", want.CreatedAt, got.CreatedAt)
+	assert.True(t, want.UpdatedAt.Equal(got.UpdatedAt), "updated_at: want %v, got %v", want.UpdatedAt, got.UpdatedAt)
+
+	wantCopy, gotCopy := *want, *got
+	wantCopy.CreatedAt, gotCopy.CreatedAt = time.Time{}, time.Time{}
+	wantCopy.UpdatedAt, gotCopy.UpdatedAt = time.Time{}, time.Time{}
+
+	if want.Cancellation != nil && got.Cancellation != nil {
+		assert.True(t, want.Cancellation.CancelledAt.Equal(got.Cancellation.CancelledAt),
+			"cancelled_at: want %v, got %v", want.Cancellation.CancelledAt, got.Cancellation.CancelledAt)
+
+		wantCancellation, gotCancellation := *want.Cancellation, *got.Cancellation
+		wantCancellation.CancelledAt, gotCancellation.CancelledAt = time.Time{}, time.Time{}
+		wantCopy.Cancellation, gotCopy.Cancellation = &wantCancellation, &gotCancellation
+	}
+
+	assert.Equal(t, wantCopy, gotCopy)
+}
+
+// assertSameHistory compares status histories, comparing times by instant.
+func assertSameHistory(t *testing.T, want, got []entities.StatusChange) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, want[i].ChangedAt.Equal(got[i].ChangedAt), "change %d changed_at: want %v, got %v", i, want[i].ChangedAt, got[i].ChangedAt)
+
+		wantCopy, gotCopy := want[i], got[i]
+		wantCopy.ChangedAt, gotCopy.ChangedAt = time.Time{}, time.Time{}
+		assert.Equal(t, wantCopy, gotCopy, "change %d", i)
+	}
+}
+
+func orderIDs(orders []*entities.Order) []string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.OrderID
+	}
+	return ids
+}
+
+func eventIDs(records []*repositories.OutboxRecord) []string {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.Event.EventID
+	}
+	return ids
+}
+
+func testCreateAndGet(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	order.IdempotencyKey = "key-1"
+	order.RequestHash = "hash-1"
+	create(t, store, order)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, order, got)
+}
+
+func testCreateWithDiscounts(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	order.PromoCode = "SPRING"
+	order.Discounts = []entities.Discount{
+		{PromoCode: "SPRING", Description: "buy 2 prod1, get 1 free", Amount: entities.NewMoney(1050, "EUR")},
+		{PromoCode: "SPRING", Description: "10% off", Amount: entities.NewMoney(239, "EUR")},
+	}
+	order.TotalAmount = entities.NewMoney(1110, "EUR")
+	create(t, store, order)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, order, got)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assertSameOrder(t, order, records[0].Event.Order)
+}
+
+func testCreateWithTax(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	order.Region = "DE"
+	order.Items[0].Category = "food"
+	order.Items[0].Tax = &entities.ItemTax{RateBasisPoints: 1000, Inclusive: true, Amount: entities.NewMoney(191, "EUR")}
+	order.Items[1].Tax = &entities.ItemTax{RateBasisPoints: 2000, Amount: entities.NewMoney(60, "EUR")}
+	order.TaxAmount = entities.NewMoney(251, "EUR")
+	order.TotalAmount = entities.NewMoney(2459, "EUR")
+	create(t, store, order)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, order, got)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assertSameOrder(t, order, records[0].Event.Order)
+}
+
+func testCreateDuplicateID(t *testing.T, store repositories.OrderStore) {
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+
+	duplicate := newOrder("user2", baseTime.Add(time.Minute))
+	duplicate.OrderID = order.OrderID
+	err := store.Create(context.Background(), duplicate, newEvent(entities.OrderEventCreated, duplicate, baseTime))
+	assert.ErrorIs(t, err, repositories.ErrOrderAlreadyExists)
+}
+
+func testIdempotencyKey(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	order := newOrder("user1", baseTime)
+	order.IdempotencyKey = "key-1"
+	order.RequestHash = "hash-1"
+	create(t, store, order)
+
+	got, err := store.GetByIdempotencyKey(ctx, "user1", "key-1")
+	require.NoError(t, err)
+	assertSameOrder(t, order, got)
+
+	_, err = store.GetByIdempotencyKey(ctx, "user2", "key-1")
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	reused := newOrder("user1", baseTime.Add(time.Minute))
+	reused.IdempotencyKey = "key-1"
+	err = store.Create(ctx, reused, newEvent(entities.OrderEventCreated, reused, baseTime))
+	assert.ErrorIs(t, err, repositories.ErrOrderAlreadyExists)
+
+	// Keys are scoped per user, and orders without a key never collide.
+	otherUser := newOrder("user2", baseTime)
+	otherUser.IdempotencyKey = "key-1"
+	create(t, store, otherUser)
+	create(t, store, newOrder("user1", baseTime))
+	create(t, store, newOrder("user1", baseTime))
+}
+
+func testGetMissing(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	_, err := store.GetByID(ctx, "missing")
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	_, err = store.GetByIdempotencyKey(ctx, "user1", "missing")
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	err = store.UpdateStatus(ctx, "missing", statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, nil)
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	_, err = store.GetHistory(ctx, "missing")
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+}
+
+func testUpdateStatus(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+
+	paid := statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, paid, 1, nil))
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderStatusPaid), got.Status)
+	assert.Equal(t, int64(2), got.Version)
+	assert.True(t, paid.ChangedAt.Equal(got.UpdatedAt))
+	assert.True(t, order.CreatedAt.Equal(got.CreatedAt))
+
+	refunded := statusChange(entities.OrderStatusPaid, entities.OrderStatusRefunded, 2)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, refunded, 2, nil))
+
+	got, err = store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderStatusRefunded), got.Status)
+	assert.Equal(t, int64(3), got.Version)
+	assert.True(t, refunded.ChangedAt.Equal(got.UpdatedAt))
+
+	// Keeping the status leaves the order as it is.
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusRefunded, entities.OrderStatusRefunded, 3), 3, nil))
+
+	got, err = store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got.Version)
+	assert.True(t, refunded.ChangedAt.Equal(got.UpdatedAt))
+}
+
+func testUpdateStatusConflicts(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+
+	err := store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 5), 5, nil)
+	assert.ErrorIs(t, err, repositories.ErrVersionConflict)
+
+	err = store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusRefunded, 1), 1, nil)
+	assert.ErrorIs(t, err, repositories.ErrInvalidTransition)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderStatusPending), got.Status)
+	assert.Equal(t, int64(1), got.Version)
+}
+
+func testCancel(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+
+	cancellation := entities.Cancellation{
+		CancelledAt: baseTime.Add(time.Hour),
+		Reason:      entities.CancelReasonOutOfStock,
+		Comment:     "prod2 is discontinued",
+		CancelledBy: "support:alice",
+	}
+	cancelled := *order
+	cancelled.Status = string(entities.OrderStatusCancelled)
+	cancelled.Version = 2
+	cancelled.UpdatedAt = cancellation.CancelledAt
+	cancelled.Cancellation = &cancellation
+	event := newEvent(entities.OrderEventCancelled, &cancelled, cancellation.CancelledAt)
+	event.PreviousStatus = string(entities.OrderStatusPending)
+
+	require.NoError(t, store.Cancel(ctx, order.OrderID, cancellation, 1, event))
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, &cancelled, got)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, entities.OrderEventCancelled, records[1].Event.Type)
+	assert.Equal(t, string(entities.OrderStatusPending), records[1].Event.PreviousStatus)
+	assertSameOrder(t, &cancelled, records[1].Event.Order)
+}
+
+func testCancelConflicts(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	cancellation := entities.Cancellation{
+		CancelledAt: baseTime,
+		Reason:      entities.CancelReasonCustomerRequest,
+		CancelledBy: "user1",
+	}
+	cancel := func(order *entities.Order, version int64) error {
+		return store.Cancel(ctx, order.OrderID, cancellation, version, newEvent(entities.OrderEventCancelled, order, baseTime))
+	}
+
+	err := cancel(&entities.Order{OrderID: "missing"}, 1)
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	pending := newOrder("user1", baseTime)
+	create(t, store, pending)
+	assert.ErrorIs(t, cancel(pending, 3), repositories.ErrVersionConflict)
+
+	require.NoError(t, cancel(pending, 1))
+	assert.ErrorIs(t, cancel(pending, 2), repositories.ErrInvalidTransition, "cancelling twice")
+
+	paid := newOrder("user1", baseTime)
+	create(t, store, paid)
+	require.NoError(t, store.UpdateStatus(ctx, paid.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, nil))
+	assert.ErrorIs(t, cancel(paid, 2), repositories.ErrInvalidTransition)
+
+	got, err := store.GetByID(ctx, paid.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderStatusPaid), got.Status)
+	assert.Nil(t, got.Cancellation)
+}
+
+// newAddress returns a complete shipping address in Berlin.
+func newAddress() *entities.ShippingAddress {
+	return &entities.ShippingAddress{
+		RecipientName: "Erika Mustermann",
+		Phone:         "+49 30 1234567",
+		Line1:         "Heidestraße 17",
+		Line2:         "3. OG",
+		City:          "Berlin",
+		PostalCode:    "10557",
+		CountryCode:   "DE",
+	}
+}
+
+func testUpdateShipping(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	order.ShippingAddress = newAddress()
+	order.DeliveryMethod = entities.DeliveryMethodCourier
+	create(t, store, order)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, order, got)
+
+	address := newAddress()
+	address.Line1 = "Invalidenstraße 50"
+	address.Line2 = ""
+	address.Phone = ""
+	address.State = "Berlin"
+	update := entities.ShippingUpdate{
+		Address:        address,
+		DeliveryMethod: entities.DeliveryMethodPost,
+		UpdatedAt:      baseTime.Add(time.Hour),
+	}
+	updated := *order
+	updated.ShippingAddress = address
+	updated.DeliveryMethod = entities.DeliveryMethodPost
+	updated.UpdatedAt = update.UpdatedAt
+	updated.Version = 2
+	require.NoError(t, store.UpdateShipping(ctx, order.OrderID, update, 1,
+		newEvent(entities.OrderEventShippingUpdated, &updated, update.UpdatedAt)))
+
+	got, err = store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, &updated, got)
+
+	// Switching to pickup drops the address.
+	pickup := entities.ShippingUpdate{DeliveryMethod: entities.DeliveryMethodPickup, UpdatedAt: baseTime.Add(2 * time.Hour)}
+	pickedUp := updated
+	pickedUp.ShippingAddress = nil
+	pickedUp.DeliveryMethod = entities.DeliveryMethodPickup
+	pickedUp.UpdatedAt = pickup.UpdatedAt
+	pickedUp.Version = 3
+	require.NoError(t, store.UpdateShipping(ctx, order.OrderID, pickup, 2,
+		newEvent(entities.OrderEventShippingUpdated, &pickedUp, pickup.UpdatedAt)))
+
+	got, err = store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, &pickedUp, got)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, entities.OrderEventShippingUpdated, records[1].Event.Type)
+	assertSameOrder(t, &updated, records[1].Event.Order)
+	assertSameOrder(t, &pickedUp, records[2].Event.Order)
+
+	// Shipping updates are not status changes.
+	history, err := store.GetHistory(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func testUpdateShippingConflicts(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	update := entities.ShippingUpdate{
+		Address:        newAddress(),
+		DeliveryMethod: entities.DeliveryMethodCourier,
+		UpdatedAt:      baseTime,
+	}
+	updateShipping := func(order *entities.Order, version int64) error {
+		return store.UpdateShipping(ctx, order.OrderID, update, version, newEvent(entities.OrderEventShippingUpdated, order, baseTime))
+	}
+
+	err := updateShipping(&entities.Order{OrderID: "missing"}, 1)
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, nil))
+	assert.ErrorIs(t, updateShipping(order, 1), repositories.ErrVersionConflict)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ShippingAddress)
+	assert.Equal(t, int64(2), got.Version)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "rejected updates record no event")
+}
+
+// applyItemsUpdate returns order as it is after update, at the next version.
+func applyItemsUpdate(order *entities.Order, update entities.ItemsUpdate) *entities.Order {
+	updated := *order
+	updated.Items = update.Items
+	updated.Subtotal = update.Subtotal
+	updated.Discounts = update.Discounts
+	updated.TaxAmount = update.TaxAmount
+	updated.TotalAmount = update.TotalAmount
+	updated.UpdatedAt = update.UpdatedAt
+	updated.Version = order.Version + 1
+	return &updated
+}
+
+func testUpdateItems(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	order.PromoCode = "SPRING"
+	order.Region = "DE"
+	order.Discounts = []entities.Discount{
+		{PromoCode: "SPRING", Description: "10% off", Amount: entities.NewMoney(240, "EUR")},
+	}
+	order.TotalAmount = entities.NewMoney(2159, "EUR")
+	create(t, store, order)
+
+	// More lines than before, with tax.
+	update := entities.ItemsUpdate{
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 3, Price: entities.NewMoney(1050, "EUR"), Category: "food",
+				Tax: &entities.ItemTax{RateBasisPoints: 1000, Inclusive: true, Amount: entities.NewMoney(258, "EUR")}},
+			{ProductID: "prod2", Quantity: 1, Price: entities.NewMoney(299, "EUR"),
+				Tax: &entities.ItemTax{RateBasisPoints: 2000, Amount: entities.NewMoney(54, "EUR")}},
+			{ProductID: "prod3", Quantity: 2, Price: entities.NewMoney(500, "EUR"),
+				Tax: &entities.ItemTax{RateBasisPoints: 2000, Amount: entities.NewMoney(180, "EUR")}},
+		},
+		Subtotal: entities.NewMoney(4449, "EUR"),
+		Discounts: []entities.Discount{
+			{PromoCode: "SPRING", Description: "10% off", Amount: entities.NewMoney(445, "EUR")},
+		},
+		TaxAmount:   entities.NewMoney(492, "EUR"),
+		TotalAmount: entities.NewMoney(4238, "EUR"),
+		UpdatedAt:   baseTime.Add(time.Hour),
+	}
+	updated := applyItemsUpdate(order, update)
+	require.NoError(t, store.UpdateItems(ctx, order.OrderID, update, 1,
+		newEvent(entities.OrderEventUpdated, updated, update.UpdatedAt)))
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, updated, got)
+
+	// Fewer lines than before, and no discount left.
+	shrink := entities.ItemsUpdate{
+		Items:       []entities.Item{{ProductID: "prod2", Quantity: 1, Price: entities.NewMoney(299, "EUR")}},
+		Subtotal:    entities.NewMoney(299, "EUR"),
+		TaxAmount:   entities.NewMoney(0, "EUR"),
+		TotalAmount: entities.NewMoney(299, "EUR"),
+		UpdatedAt:   baseTime.Add(2 * time.Hour),
+	}
+	shrunk := applyItemsUpdate(updated, shrink)
+	require.NoError(t, store.UpdateItems(ctx, order.OrderID, shrink, 2,
+		newEvent(entities.OrderEventUpdated, shrunk, shrink.UpdatedAt)))
+
+	got, err = store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameOrder(t, shrunk, got)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, entities.OrderEventUpdated, records[1].Event.Type)
+	assertSameOrder(t, updated, records[1].Event.Order)
+	assertSameOrder(t, shrunk, records[2].Event.Order)
+
+	// Item edits are not status changes.
+	history, err := store.GetHistory(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func testUpdateItemsConflicts(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	update := entities.ItemsUpdate{
+		Items:       []entities.Item{{ProductID: "prod1", Quantity: 1, Price: entities.NewMoney(1050, "EUR")}},
+		Subtotal:    entities.NewMoney(1050, "EUR"),
+		TaxAmount:   entities.NewMoney(0, "EUR"),
+		TotalAmount: entities.NewMoney(1050, "EUR"),
+		UpdatedAt:   baseTime,
+	}
+	updateItems := func(order *entities.Order, version int64) error {
+		return store.UpdateItems(ctx, order.OrderID, update, version, newEvent(entities.OrderEventUpdated, order, baseTime))
+	}
+
+	err := updateItems(&entities.Order{OrderID: "missing"}, 1)
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, nil))
+	assert.ErrorIs(t, updateItems(order, 1), repositories.ErrVersionConflict)
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.Items, got.Items)
+	assert.Equal(t, order.TotalAmount, got.TotalAmount)
+	assert.Equal(t, int64(2), got.Version)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "rejected updates record no event")
+}
+
+func testHistory(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+	order := newOrder("user1", baseTime)
+	create(t, store, order)
+
+	paid := statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, paid, 1, nil))
+
+	// Keeping the status and rejected updates add nothing.
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPaid, entities.OrderStatusPaid, 2), 2, nil))
+	err := store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPaid, entities.OrderStatusRefunded, 1), 1, nil)
+	require.ErrorIs(t, err, repositories.ErrVersionConflict)
+	err = store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPaid, entities.OrderStatusPending, 2), 2, nil)
+	require.ErrorIs(t, err, repositories.ErrInvalidTransition)
+
+	// The versions in the history have no gaps.
+	refunded := statusChange(entities.OrderStatusPaid, entities.OrderStatusRefunded, 2)
+	refunded.Actor, refunded.Reason = "", ""
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, refunded, 2, nil))
+
+	history, err := store.GetHistory(ctx, order.OrderID)
+	require.NoError(t, err)
+	assertSameHistory(t, []entities.StatusChange{order.CreationChange(), paid, refunded}, history)
+
+	cancelledOrder := newOrder("user2", baseTime)
+	create(t, store, cancelledOrder)
+	cancellation := entities.Cancellation{
+		CancelledAt: baseTime.Add(time.Hour),
+		Reason:      entities.CancelReasonPaymentTimeout,
+		CancelledBy: "payment-service",
+	}
+	require.NoError(t, store.Cancel(ctx, cancelledOrder.OrderID, cancellation, 1,
+		newEvent(entities.OrderEventCancelled, cancelledOrder, cancellation.CancelledAt)))
+
+	history, err = store.GetHistory(ctx, cancelledOrder.OrderID)
+	require.NoError(t, err)
+	assertSameHistory(t, []entities.StatusChange{
+		cancelledOrder.CreationChange(),
+		{
+			From:      string(entities.OrderStatusPending),
+			To:        string(entities.OrderStatusCancelled),
+			ChangedAt: cancellation.CancelledAt,
+			Actor:     "payment-service",
+			Reason:    string(entities.CancelReasonPaymentTimeout),
+			Version:   2,
+		},
+	}, history)
+}
+
+func testListFilters(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	oldest := newOrder("user1", baseTime)
+	middle := newOrder("user1", baseTime.Add(time.Hour))
+	newest := newOrder("user1", baseTime.Add(2*time.Hour))
+	otherUser := newOrder("user2", baseTime.Add(time.Hour))
+	for _, order := range []*entities.Order{oldest, middle, newest, otherUser} {
+		create(t, store, order)
+	}
+	require.NoError(t, store.UpdateStatus(ctx, middle.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, nil))
+
+	// Orders created at the same instant tie-break on ID descending.
+	sameInstant := []*entities.Order{middle, otherUser}
+	if otherUser.OrderID > middle.OrderID {
+		sameInstant = []*entities.Order{otherUser, middle}
+	}
+	all := append(append([]*entities.Order{newest}, sameInstant...), oldest)
+
+	tests := []struct {
+		name   string
+		filter repositories.OrderFilter
+		want   []*entities.Order
+	}{
+		{"all", repositories.OrderFilter{}, all},
+		{"by user", repositories.OrderFilter{UserID: "user1"}, []*entities.Order{newest, middle, oldest}},
+		{"by status", repositories.OrderFilter{UserID: "user1", Statuses: []string{"PAID"}}, []*entities.Order{middle}},
+		{"by statuses", repositories.OrderFilter{Statuses: []string{"PENDING", "CANCELLED"}}, []*entities.Order{newest, otherUser, oldest}},
+		{"created from is inclusive", repositories.OrderFilter{UserID: "user1", CreatedFrom: middle.CreatedAt}, []*entities.Order{newest, middle}},
+		{"created to is exclusive", repositories.OrderFilter{UserID: "user1", CreatedTo: middle.CreatedAt}, []*entities.Order{oldest}},
+		{"limit", repositories.OrderFilter{UserID: "user1", Limit: 2}, []*entities.Order{newest, middle}},
+		{"no match", repositories.OrderFilter{UserID: "nobody"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.List(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, orderIDs(tt.want), orderIDs(got))
+		})
+	}
+}
+
+func testListPagination(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	// Two orders share each timestamp so the cursor must tie-break on ID.
+	var want []string
+	for i := 0; i < 3; i++ {
+		createdAt := baseTime.Add(time.Duration(i) * time.Minute)
+		a, b := newOrder("user1", createdAt), newOrder("user1", createdAt)
+		create(t, store, a)
+		create(t, store, b)
+
+		first, second := a.OrderID, b.OrderID
+		if first < second {
+			first, second = second, first
+		}
+		want = append([]string{first, second}, want...)
+	}
+
+	var got []string
+	var after *repositories.OrderCursor
+	for page := 0; page < 10; page++ {
+		orders, err := store.List(ctx, repositories.OrderFilter{UserID: "user1", After: after, Limit: 4})
+		require.NoError(t, err)
+		if len(orders) == 0 {
+			break
+		}
+		got = append(got, orderIDs(orders)...)
+
+		last := orders[len(orders)-1]
+		after = &repositories.OrderCursor{CreatedAt: last.CreatedAt, OrderID: last.OrderID}
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func testOutbox(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	order := newOrder("user1", baseTime)
+	created := newEvent(entities.OrderEventCreated, order, baseTime)
+	created.TraceContext = map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	require.NoError(t, store.Create(ctx, order, created))
+
+	paid := *order
+	paid.Status = string(entities.OrderStatusPaid)
+	paid.Version = 2
+	statusChanged := newEvent(entities.OrderEventStatusChanged, &paid, baseTime.Add(time.Second))
+	statusChanged.PreviousStatus = string(entities.OrderStatusPending)
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 1), 1, statusChanged))
+
+	// An update without an event records nothing.
+	require.NoError(t, store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPaid, entities.OrderStatusPaid, 2), 2, nil))
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{created.EventID, statusChanged.EventID}, eventIDs(records))
+
+	first := records[0]
+	assert.Equal(t, entities.OrderEventCreated, first.Event.Type)
+	assert.Equal(t, 0, first.Attempts)
+	assert.True(t, created.OccurredAt.Equal(first.Event.OccurredAt))
+	assert.Equal(t, created.TraceContext, first.Event.TraceContext)
+	assertSameOrder(t, order, first.Event.Order)
+
+	second := records[1]
+	assert.Equal(t, entities.OrderEventStatusChanged, second.Event.Type)
+	assert.Equal(t, string(entities.OrderStatusPending), second.Event.PreviousStatus)
+	assert.Equal(t, string(entities.OrderStatusPaid), second.Event.Order.Status)
+
+	limited, err := store.FetchPending(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{created.EventID}, eventIDs(limited))
+
+	require.NoError(t, store.MarkDelivered(ctx, created.EventID))
+
+	records, err = store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{statusChanged.EventID}, eventIDs(records))
+}
+
+func testOutboxRetry(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	order := newOrder("user1", baseTime)
+	event := create(t, store, order)
+
+	require.NoError(t, store.MarkFailed(ctx, event.EventID, time.Now().Add(time.Hour), fmt.Errorf("nats down")))
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records, "event postponed into the future must not be due")
+
+	require.NoError(t, store.MarkFailed(ctx, event.EventID, time.Now().Add(-time.Second), fmt.Errorf("nats down")))
+
+	records, err = store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, event.EventID, records[0].Event.EventID)
+	assert.Equal(t, 2, records[0].Attempts)
+}
+
+func testOutboxAtomicity(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	order := newOrder("user1", baseTime)
+	event := create(t, store, order)
+	require.NoError(t, store.MarkDelivered(ctx, event.EventID))
+
+	duplicate := newOrder("user1", baseTime)
+	duplicate.OrderID = order.OrderID
+	err := store.Create(ctx, duplicate, newEvent(entities.OrderEventCreated, duplicate, baseTime))
+	require.ErrorIs(t, err, repositories.ErrOrderAlreadyExists)
+
+	rejected := newEvent(entities.OrderEventStatusChanged, order, baseTime)
+	err = store.UpdateStatus(ctx, order.OrderID, statusChange(entities.OrderStatusPending, entities.OrderStatusPaid, 7), 7, rejected)
+	require.ErrorIs(t, err, repositories.ErrVersionConflict)
+
+	records, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records, "failed writes must not record events")
+}
+
+func testReturnedOrdersAreCopies(t *testing.T, store repositories.OrderStore) {
+	ctx := context.Background()
+
+	order := newOrder("user1", baseTime)
+	order.Items[0].Tax = &entities.ItemTax{RateBasisPoints: 2000, Amount: entities.NewMoney(420, "EUR")}
+	order.ShippingAddress = newAddress()
+	create(t, store, order)
+	order.Items[0].Quantity = 99
+	order.Items[0].Tax.Amount.Amount = 1
+	order.ShippingAddress.City = "Hamburg"
+
+	got, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Items[0].Quantity)
+	assert.Equal(t, int64(420), got.Items[0].Tax.Amount.Amount)
+
+	got.Items[0].Quantity = 42
+	got.Items[0].Tax.Amount.Amount = 2
+	got.ShippingAddress.City = "Munich"
+	got.Status = string(entities.OrderStatusPaid)
+
+	again, err := store.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, again.Items[0].Quantity)
+	assert.Equal(t, int64(420), again.Items[0].Tax.Amount.Amount)
+	assert.Equal(t, "Berlin", again.ShippingAddress.City)
+	assert.Equal(t, string(entities.OrderStatusPending), again.Status)
+}
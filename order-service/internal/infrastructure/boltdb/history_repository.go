@@ -0,0 +1,55 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+func (r *OrderRepositoryBolt) GetHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error) {
+	history := make([]entities.StatusChange, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(ordersBucket).Get([]byte(orderID)) == nil {
+			return repositories.ErrOrderNotFound
+		}
+
+		prefix := append([]byte(orderID), 0)
+		c := tx.Bucket(statusHistoryBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var change entities.StatusChange
+			if err := json.Unmarshal(v, &change); err != nil {
+				return fmt.Errorf("failed to decode status change of order %s: %w", orderID, err)
+			}
+			history = append(history, change)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func appendStatusChange(tx *bbolt.Tx, orderID string, change entities.StatusChange) error {
+	if err := putJSON(tx.Bucket(statusHistoryBucket), historyKey(orderID, change.Version), change); err != nil {
+		return fmt.Errorf("failed to append status change: %w", err)
+	}
+	return nil
+}
+
+// historyKey builds the order ID, a zero byte and version as a big-endian
+// integer, so that an order's status changes are adjacent and in order.
+func historyKey(orderID string, version int64) []byte {
+	key := make([]byte, 0, len(orderID)+1+8)
+	key = append(key, orderID...)
+	key = append(key, 0)
+	return binary.BigEndian.AppendUint64(key, uint64(version))
+}
@@ -0,0 +1,179 @@
+package boltdb
+
+import (
+	"time"
+
+	"order-service/internal/domain/entities"
+)
+
+// OrderDocument is the stored form of an order, encoded as JSON in the orders
+// bucket under its order ID. Amounts are int64 minor units of Currency.
+// UpdatedAt, SubtotalMinor, the tax and the shipping fields are absent on
+// orders written before they were introduced.
+type OrderDocument struct {
+	OrderID        string             `json:"order_id"`
+	UserID         string             `json:"user_id"`
+	Items          []ItemDocument     `json:"items"`
+	Currency       string             `json:"currency"`
+	SubtotalMinor  int64              `json:"subtotal_minor,omitempty"`
+	PromoCode      string             `json:"promo_code,omitempty"`
+	Discounts      []DiscountDocument `json:"discounts,omitempty"`
+	Region         string             `json:"region,omitempty"`
+	TaxMinor       int64              `json:"tax_minor,omitempty"`
+	TotalMinor     int64              `json:"total_minor"`
+	DeliveryMethod string             `json:"delivery_method,omitempty"`
+	Status         string             `json:"status"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	Version        int64              `json:"version"`
+	IdempotencyKey string             `json:"idempotency_key,omitempty"`
+	RequestHash    string             `json:"request_hash,omitempty"`
+
+	ShippingAddress *entities.ShippingAddress `json:"shipping_address,omitempty"`
+	Cancellation    *entities.Cancellation    `json:"cancellation,omitempty"`
+}
+
+type ItemDocument struct {
+	ProductID  string       `json:"product_id"`
+	Quantity   int          `json:"quantity"`
+	PriceMinor int64        `json:"price_minor"`
+	Category   string       `json:"category,omitempty"`
+	Tax        *TaxDocument `json:"tax,omitempty"`
+}
+
+type TaxDocument struct {
+	RateBasisPoints int64 `json:"rate_basis_points"`
+	Inclusive       bool  `json:"inclusive"`
+	AmountMinor     int64 `json:"amount_minor"`
+}
+
+type DiscountDocument struct {
+	PromoCode   string `json:"promo_code"`
+	Description string `json:"description"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// OutboxDocument is an undelivered event, stored in the outbox bucket under a
+// sequence number so that iteration yields events in the order they were
+// recorded. Delivered events are deleted.
+type OutboxDocument struct {
+	EventID        string            `json:"event_id"`
+	EventType      string            `json:"event_type"`
+	Order          OrderDocument     `json:"order"`
+	PreviousStatus string            `json:"previous_status,omitempty"`
+	OccurredAt     time.Time         `json:"occurred_at"`
+	Attempts       int               `json:"attempts"`
+	NextAttemptAt  time.Time         `json:"next_attempt_at"`
+	LastError      string            `json:"last_error,omitempty"`
+	TraceContext   map[string]string `json:"trace_context,omitempty"`
+}
+
+func toOrderDocument(order *entities.Order) *OrderDocument {
+	doc := &OrderDocument{
+		OrderID:         order.OrderID,
+		UserID:          order.UserID,
+		Currency:        order.Currency,
+		SubtotalMinor:   order.Subtotal.Amount,
+		PromoCode:       order.PromoCode,
+		Region:          order.Region,
+		TaxMinor:        order.TaxAmount.Amount,
+		TotalMinor:      order.TotalAmount.Amount,
+		ShippingAddress: order.ShippingAddress,
+		DeliveryMethod:  string(order.DeliveryMethod),
+		Status:          order.Status,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+		Version:         order.Version,
+		Items:           make([]ItemDocument, len(order.Items)),
+		IdempotencyKey:  order.IdempotencyKey,
+		RequestHash:     order.RequestHash,
+		Cancellation:    order.Cancellation,
+	}
+
+	for i, item := range order.Items {
+		doc.Items[i] = ItemDocument{
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			PriceMinor: item.Price.Amount,
+			Category:   item.Category,
+		}
+		if item.Tax != nil {
+			doc.Items[i].Tax = &TaxDocument{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				AmountMinor:     item.Tax.Amount.Amount,
+			}
+		}
+	}
+
+	for _, discount := range order.Discounts {
+		doc.Discounts = append(doc.Discounts, DiscountDocument{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			AmountMinor: discount.Amount.Amount,
+		})
+	}
+
+	return doc
+}
+
+func toOrderEntity(doc *OrderDocument) *entities.Order {
+	items := make([]entities.Item, len(doc.Items))
+	for i, item := range doc.Items {
+		items[i] = entities.Item{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     entities.NewMoney(item.PriceMinor, doc.Currency),
+			Category:  item.Category,
+		}
+		if item.Tax != nil {
+			items[i].Tax = &entities.ItemTax{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				Amount:          entities.NewMoney(item.Tax.AmountMinor, doc.Currency),
+			}
+		}
+	}
+
+	var discounts []entities.Discount
+	for _, discount := range doc.Discounts {
+		discounts = append(discounts, entities.Discount{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			Amount:      entities.NewMoney(discount.AmountMinor, doc.Currency),
+		})
+	}
+
+	updatedAt := doc.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = doc.CreatedAt
+	}
+
+	// Orders written before discounts existed were never discounted.
+	subtotalMinor := doc.SubtotalMinor
+	if subtotalMinor == 0 && len(doc.Discounts) == 0 {
+		subtotalMinor = doc.TotalMinor
+	}
+
+	return &entities.Order{
+		OrderID:         doc.OrderID,
+		UserID:          doc.UserID,
+		Items:           items,
+		Currency:        doc.Currency,
+		Subtotal:        entities.NewMoney(subtotalMinor, doc.Currency),
+		PromoCode:       doc.PromoCode,
+		Discounts:       discounts,
+		Region:          doc.Region,
+		TaxAmount:       entities.NewMoney(doc.TaxMinor, doc.Currency),
+		TotalAmount:     entities.NewMoney(doc.TotalMinor, doc.Currency),
+		ShippingAddress: doc.ShippingAddress,
+		DeliveryMethod:  entities.DeliveryMethod(doc.DeliveryMethod),
+		Status:          doc.Status,
+		CreatedAt:       doc.CreatedAt,
+		UpdatedAt:       updatedAt,
+		Version:         doc.Version,
+		IdempotencyKey:  doc.IdempotencyKey,
+		RequestHash:     doc.RequestHash,
+		Cancellation:    doc.Cancellation,
+	}
+}
@@ -0,0 +1,387 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+var _ repositories.OrderStore = (*OrderRepositoryBolt)(nil)
+
+var (
+	ordersBucket = []byte("orders")
+	// idempotencyKeysBucket maps user ID and idempotency key to order ID.
+	idempotencyKeysBucket = []byte("idempotency_keys")
+	// The index buckets hold empty values under keys built by indexKey, so
+	// that iterating a prefix backwards yields orders in List ordering.
+	ordersByUserBucket    = []byte("orders_by_user")
+	ordersByStatusBucket  = []byte("orders_by_status")
+	ordersByCreatedBucket = []byte("orders_by_created")
+	outboxBucket          = []byte("outbox")
+	// outboxEventsBucket maps event ID to the event's key in outboxBucket.
+	outboxEventsBucket = []byte("outbox_events")
+	// statusHistoryBucket holds status changes under keys built by historyKey.
+	statusHistoryBucket = []byte("status_history")
+)
+
+// OrderRepositoryBolt stores orders and their outbox in a single bbolt file,
+// for single-node deployments without MongoDB. Every write is one bbolt
+// transaction that is fsynced before it returns, so an order and its event
+// survive a crash together or not at all. Only one process may open the file.
+type OrderRepositoryBolt struct {
+	db *bbolt.DB
+}
+
+func NewOrderRepositoryBolt(path string) (*OrderRepositoryBolt, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{
+			ordersBucket,
+			idempotencyKeysBucket,
+			ordersByUserBucket,
+			ordersByStatusBucket,
+			ordersByCreatedBucket,
+			outboxBucket,
+			outboxEventsBucket,
+			statusHistoryBucket,
+		} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create buckets: %w", err)
+	}
+
+	return &OrderRepositoryBolt{db: db}, nil
+}
+
+func (r *OrderRepositoryBolt) Close() error {
+	return r.db.Close()
+}
+
+func (r *OrderRepositoryBolt) Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		orders := tx.Bucket(ordersBucket)
+		if orders.Get([]byte(order.OrderID)) != nil {
+			return repositories.ErrOrderAlreadyExists
+		}
+
+		if order.IdempotencyKey != "" {
+			keys := tx.Bucket(idempotencyKeysBucket)
+			key := idempotencyKey(order.UserID, order.IdempotencyKey)
+			if keys.Get(key) != nil {
+				return repositories.ErrOrderAlreadyExists
+			}
+			if err := keys.Put(key, []byte(order.OrderID)); err != nil {
+				return fmt.Errorf("failed to store idempotency key: %w", err)
+			}
+		}
+
+		if err := putJSON(orders, []byte(order.OrderID), toOrderDocument(order)); err != nil {
+			return fmt.Errorf("failed to insert order: %w", err)
+		}
+		if err := putIndexes(tx, order); err != nil {
+			return err
+		}
+		if err := appendStatusChange(tx, order.OrderID, order.CreationChange()); err != nil {
+			return err
+		}
+		return appendOutboxEvent(tx, event)
+	})
+}
+
+func (r *OrderRepositoryBolt) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
+	var order *entities.Order
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		order, err = getOrder(tx, orderID)
+		return err
+	})
+	return order, err
+}
+
+func (r *OrderRepositoryBolt) GetByIdempotencyKey(ctx context.Context, userID, key string) (*entities.Order, error) {
+	var order *entities.Order
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		orderID := tx.Bucket(idempotencyKeysBucket).Get(idempotencyKey(userID, key))
+		if orderID == nil {
+			return repositories.ErrOrderNotFound
+		}
+
+		var err error
+		order, err = getOrder(tx, string(orderID))
+		return err
+	})
+	return order, err
+}
+
+func (r *OrderRepositoryBolt) UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		order, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+		if !entities.CanTransition(order.Status, change.To) {
+			return repositories.ErrInvalidTransition
+		}
+		if order.Status == change.To {
+			return nil
+		}
+
+		if err := appendStatusChange(tx, orderID, change); err != nil {
+			return err
+		}
+		order.UpdatedAt = change.ChangedAt
+		if err := setStatus(tx, order, change.To); err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		return appendOutboxEvent(tx, event)
+	})
+}
+
+func (r *OrderRepositoryBolt) Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		order, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+		if !entities.OrderStatus(order.Status).Cancellable() {
+			return repositories.ErrInvalidTransition
+		}
+
+		if err := appendStatusChange(tx, orderID, cancellation.StatusChange(order.Status, order.Version+1)); err != nil {
+			return err
+		}
+		order.Cancellation = &cancellation
+		order.UpdatedAt = cancellation.CancelledAt
+		if err := setStatus(tx, order, string(entities.OrderStatusCancelled)); err != nil {
+			return err
+		}
+		return appendOutboxEvent(tx, event)
+	})
+}
+
+func (r *OrderRepositoryBolt) UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		order, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+
+		order.ShippingAddress = update.Address
+		order.DeliveryMethod = update.DeliveryMethod
+		order.UpdatedAt = update.UpdatedAt
+		order.Version++
+		if err := putJSON(tx.Bucket(ordersBucket), []byte(order.OrderID), toOrderDocument(order)); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return appendOutboxEvent(tx, event)
+	})
+}
+
+func (r *OrderRepositoryBolt) UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		order, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+
+		order.Items = update.Items
+		order.Subtotal = update.Subtotal
+		order.Discounts = update.Discounts
+		order.TaxAmount = update.TaxAmount
+		order.TotalAmount = update.TotalAmount
+		order.UpdatedAt = update.UpdatedAt
+		order.Version++
+		if err := putJSON(tx.Bucket(ordersBucket), []byte(order.OrderID), toOrderDocument(order)); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return appendOutboxEvent(tx, event)
+	})
+}
+
+// setStatus moves order to status, bumps its version and stores it together
+// with the status index.
+func setStatus(tx *bbolt.Tx, order *entities.Order, status string) error {
+	statuses := tx.Bucket(ordersByStatusBucket)
+	if err := statuses.Delete(indexKey(order.Status, order.CreatedAt, order.OrderID)); err != nil {
+		return fmt.Errorf("failed to update status index: %w", err)
+	}
+	if err := statuses.Put(indexKey(status, order.CreatedAt, order.OrderID), nil); err != nil {
+		return fmt.Errorf("failed to update status index: %w", err)
+	}
+
+	order.Status = status
+	order.Version++
+	if err := putJSON(tx.Bucket(ordersBucket), []byte(order.OrderID), toOrderDocument(order)); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	return nil
+}
+
+// List walks the most selective index backwards from the upper bound implied
+// by the filter and stops at its lower bound or once Limit orders are found.
+// The user index is used when the filter names a user, the status index when
+// it names a single status, and the created_at index otherwise.
+func (r *OrderRepositoryBolt) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
+	bucket, prefix := ordersByCreatedBucket, ""
+	switch {
+	case filter.UserID != "":
+		bucket, prefix = ordersByUserBucket, filter.UserID
+	case len(filter.Statuses) == 1:
+		bucket, prefix = ordersByStatusBucket, filter.Statuses[0]
+	}
+
+	orders := make([]*entities.Order, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		scope := append([]byte(prefix), 0)
+
+		upper := append([]byte(prefix), 1)
+		if filter.After != nil {
+			upper = indexKey(prefix, filter.After.CreatedAt, filter.After.OrderID)
+		}
+		if !filter.CreatedTo.IsZero() {
+			if createdTo := indexKey(prefix, filter.CreatedTo, ""); bytes.Compare(createdTo, upper) < 0 {
+				upper = createdTo
+			}
+		}
+
+		var lower []byte
+		if !filter.CreatedFrom.IsZero() {
+			lower = indexKey(prefix, filter.CreatedFrom, "")
+		}
+
+		// Seek lands on the first key at or after upper, which is excluded.
+		k, _ := c.Seek(upper)
+		if k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+
+		for ; k != nil && bytes.HasPrefix(k, scope); k, _ = c.Prev() {
+			if lower != nil && bytes.Compare(k, lower) < 0 {
+				break
+			}
+
+			order, err := getOrder(tx, string(k[len(scope)+8:]))
+			if err != nil {
+				return err
+			}
+			if len(filter.Statuses) > 0 && !containsString(filter.Statuses, order.Status) {
+				continue
+			}
+
+			orders = append(orders, order)
+			if filter.Limit > 0 && len(orders) == filter.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+func getOrder(tx *bbolt.Tx, orderID string) (*entities.Order, error) {
+	data := tx.Bucket(ordersBucket).Get([]byte(orderID))
+	if data == nil {
+		return nil, repositories.ErrOrderNotFound
+	}
+
+	var doc OrderDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode order %s: %w", orderID, err)
+	}
+	return toOrderEntity(&doc), nil
+}
+
+func putIndexes(tx *bbolt.Tx, order *entities.Order) error {
+	indexes := []struct {
+		bucket []byte
+		prefix string
+	}{
+		{ordersByUserBucket, order.UserID},
+		{ordersByStatusBucket, order.Status},
+		{ordersByCreatedBucket, ""},
+	}
+
+	for _, index := range indexes {
+		if err := tx.Bucket(index.bucket).Put(indexKey(index.prefix, order.CreatedAt, order.OrderID), nil); err != nil {
+			return fmt.Errorf("failed to update %s index: %w", index.bucket, err)
+		}
+	}
+	return nil
+}
+
+// indexKey builds prefix, a zero byte, createdAt as a big-endian sortable
+// integer and the order ID, so that byte order matches List ordering reversed.
+func indexKey(prefix string, createdAt time.Time, orderID string) []byte {
+	key := make([]byte, 0, len(prefix)+1+8+len(orderID))
+	key = append(key, prefix...)
+	key = append(key, 0)
+	key = binary.BigEndian.AppendUint64(key, uint64(createdAt.UnixNano())^(1<<63))
+	return append(key, orderID...)
+}
+
+func idempotencyKey(userID, key string) []byte {
+	return []byte(userID + "\x00" + key)
+}
+
+func putJSON(bucket *bbolt.Bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, data)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package boltdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T, path string) *OrderRepositoryBolt {
+	repo, err := NewOrderRepositoryBolt(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestOrderRepositoryBolt_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositories.OrderStore {
+		return newTestRepository(t, filepath.Join(t.TempDir(), "orders.db"))
+	})
+}
+
+func TestOrderRepositoryBolt_SurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "orders.db")
+
+	order := &entities.Order{
+		OrderID:     "order-1",
+		UserID:      "user123",
+		Items:       []entities.Item{{ProductID: "prod1", Quantity: 2, Price: entities.NewMoney(1050, "EUR")}},
+		Currency:    "EUR",
+		TotalAmount: entities.NewMoney(2100, "EUR"),
+		Status:      string(entities.OrderStatusPending),
+		CreatedAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Version:     1,
+	}
+	event := &entities.OrderEvent{EventID: "event-1", Type: entities.OrderEventCreated, Order: order, OccurredAt: order.CreatedAt}
+
+	repo, err := NewOrderRepositoryBolt(path)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, order, event))
+	paid := entities.StatusChange{From: order.Status, To: string(entities.OrderStatusPaid), ChangedAt: order.CreatedAt.Add(time.Hour), Version: 2}
+	require.NoError(t, repo.UpdateStatus(ctx, order.OrderID, paid, 1, nil))
+	require.NoError(t, repo.Close())
+
+	repo = newTestRepository(t, path)
+
+	got, err := repo.GetByID(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderStatusPaid), got.Status)
+	assert.Equal(t, int64(2), got.Version)
+
+	paidOrders, err := repo.List(ctx, repositories.OrderFilter{Statuses: []string{"PAID"}})
+	require.NoError(t, err)
+	require.Len(t, paidOrders, 1)
+	assert.Equal(t, order.OrderID, paidOrders[0].OrderID)
+
+	history, err := repo.GetHistory(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.StatusChange{order.CreationChange(), paid}, history)
+
+	pending, err := repo.List(ctx, repositories.OrderFilter{Statuses: []string{"PENDING"}})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	records, err := repo.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, event.EventID, records[0].Event.EventID)
+}
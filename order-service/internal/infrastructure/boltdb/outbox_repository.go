@@ -0,0 +1,128 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+func (r *OrderRepositoryBolt) FetchPending(ctx context.Context, limit int) ([]*repositories.OutboxRecord, error) {
+	now := time.Now()
+	records := make([]*repositories.OutboxRecord, 0)
+
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(outboxBucket).Cursor()
+		for k, v := c.First(); k != nil && len(records) < limit; k, v = c.Next() {
+			var doc OutboxDocument
+			if err := json.Unmarshal(v, &doc); err != nil {
+				return fmt.Errorf("failed to decode outbox event: %w", err)
+			}
+			if doc.NextAttemptAt.After(now) {
+				continue
+			}
+			records = append(records, toOutboxRecord(&doc))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending events: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *OrderRepositoryBolt) MarkDelivered(ctx context.Context, eventID string) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		events := tx.Bucket(outboxEventsBucket)
+		key := events.Get([]byte(eventID))
+		if key == nil {
+			return nil
+		}
+
+		if err := tx.Bucket(outboxBucket).Delete(key); err != nil {
+			return err
+		}
+		return events.Delete([]byte(eventID))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark event delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *OrderRepositoryBolt) MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) error {
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		key := tx.Bucket(outboxEventsBucket).Get([]byte(eventID))
+		if key == nil {
+			return nil
+		}
+
+		outbox := tx.Bucket(outboxBucket)
+		var doc OutboxDocument
+		if err := json.Unmarshal(outbox.Get(key), &doc); err != nil {
+			return err
+		}
+
+		doc.NextAttemptAt = nextAttemptAt
+		doc.LastError = cause.Error()
+		doc.Attempts++
+		return putJSON(outbox, key, &doc)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+	return nil
+}
+
+// appendOutboxEvent stores event under the next sequence number of the outbox
+// bucket, within the caller's transaction.
+func appendOutboxEvent(tx *bbolt.Tx, event *entities.OrderEvent) error {
+	outbox := tx.Bucket(outboxBucket)
+
+	seq, err := outbox.NextSequence()
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	key := binary.BigEndian.AppendUint64(nil, seq)
+
+	if err := putJSON(outbox, key, toOutboxDocument(event)); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	if err := tx.Bucket(outboxEventsBucket).Put([]byte(event.EventID), key); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+func toOutboxDocument(event *entities.OrderEvent) *OutboxDocument {
+	return &OutboxDocument{
+		EventID:        event.EventID,
+		EventType:      string(event.Type),
+		Order:          *toOrderDocument(event.Order),
+		PreviousStatus: event.PreviousStatus,
+		OccurredAt:     event.OccurredAt,
+		NextAttemptAt:  event.OccurredAt,
+		TraceContext:   event.TraceContext,
+	}
+}
+
+func toOutboxRecord(doc *OutboxDocument) *repositories.OutboxRecord {
+	return &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID:        doc.EventID,
+			Type:           entities.OrderEventType(doc.EventType),
+			Order:          toOrderEntity(&doc.Order),
+			PreviousStatus: doc.PreviousStatus,
+			OccurredAt:     doc.OccurredAt,
+			TraceContext:   doc.TraceContext,
+		},
+		Attempts: doc.Attempts,
+	}
+}
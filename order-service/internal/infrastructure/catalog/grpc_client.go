@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/infrastructure/catalog/proto"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// requestTimeout bounds a catalog lookup when the caller's context has no
+// earlier deadline, so that a slow catalog cannot hold up order creation.
+const requestTimeout = 3 * time.Second
+
+// GRPCCatalog looks products up in a remote product catalog service.
+type GRPCCatalog struct {
+	conn   *grpc.ClientConn
+	client proto.ProductCatalogClient
+}
+
+// NewGRPCCatalog creates a client for the catalog service at addr. The
+// connection is established lazily, on the first lookup.
+func NewGRPCCatalog(addr string) (*GRPCCatalog, error) {
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product catalog client: %w", err)
+	}
+
+	return &GRPCCatalog{conn: conn, client: proto.NewProductCatalogClient(conn)}, nil
+}
+
+func (c *GRPCCatalog) GetProducts(ctx context.Context, productIDs []string) (map[string]entities.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := c.client.GetProducts(ctx, &proto.GetProductsRequest{ProductIds: productIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	products := make(map[string]entities.Product, len(resp.Products))
+	for _, p := range resp.Products {
+		products[p.ProductId] = entities.Product{
+			ProductID: p.ProductId,
+			Price:     entities.NewMoney(p.Price, p.Currency),
+			Available: p.Available,
+			Category:  p.Category,
+		}
+	}
+	return products, nil
+}
+
+func (c *GRPCCatalog) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/infrastructure/catalog/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeCatalogServer struct {
+	proto.UnimplementedProductCatalogServer
+	products map[string]*proto.Product
+	err      error
+}
+
+func (s *fakeCatalogServer) GetProducts(_ context.Context, req *proto.GetProductsRequest) (*proto.GetProductsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	resp := &proto.GetProductsResponse{}
+	for _, id := range req.ProductIds {
+		if p, ok := s.products[id]; ok {
+			resp.Products = append(resp.Products, p)
+		}
+	}
+	return resp, nil
+}
+
+func startCatalogServer(t *testing.T, srv *fakeCatalogServer) *GRPCCatalog {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	proto.RegisterProductCatalogServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	catalog, err := NewGRPCCatalog(lis.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { catalog.Close() })
+	return catalog
+}
+
+func TestGRPCCatalog_GetProducts(t *testing.T) {
+	catalog := startCatalogServer(t, &fakeCatalogServer{products: map[string]*proto.Product{
+		"prod1": {ProductId: "prod1", Price: 1000, Currency: "RUB", Available: true, Category: "food"},
+		"prod2": {ProductId: "prod2", Price: 500, Currency: "RUB"},
+	}})
+
+	products, err := catalog.GetProducts(context.Background(), []string{"prod1", "prod2", "missing"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]entities.Product{
+		"prod1": {ProductID: "prod1", Price: entities.NewMoney(1000, "RUB"), Available: true, Category: "food"},
+		"prod2": {ProductID: "prod2", Price: entities.NewMoney(500, "RUB"), Available: false},
+	}, products)
+}
+
+func TestGRPCCatalog_GetProducts_Error(t *testing.T) {
+	catalog := startCatalogServer(t, &fakeCatalogServer{err: status.Error(codes.Unavailable, "catalog is down")})
+
+	_, err := catalog.GetProducts(context.Background(), []string{"prod1"})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"order-service/internal/domain/entities"
+)
+
+// StaticCatalog serves products from a JSON file read once at startup.
+type StaticCatalog struct {
+	products map[string]entities.Product
+}
+
+// productEntry is a product as written in the catalog file. The price is in
+// minor units of currency; products are available unless stated otherwise.
+type productEntry struct {
+	ProductID string `json:"product_id"`
+	Price     int64  `json:"price"`
+	Currency  string `json:"currency"`
+	Available *bool  `json:"available,omitempty"`
+	Category  string `json:"category,omitempty"`
+}
+
+// NewStaticCatalog loads the products listed in the JSON file at path, e.g.
+//
+//	[{"product_id": "prod1", "price": 1000, "currency": "RUB", "category": "food"},
+//	 {"product_id": "prod2", "price": 500, "currency": "RUB", "available": false}]
+func NewStaticCatalog(path string) (*StaticCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read product catalog: %w", err)
+	}
+
+	var entries []productEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse product catalog %s: %w", path, err)
+	}
+
+	products := make(map[string]entities.Product, len(entries))
+	for i, entry := range entries {
+		switch {
+		case entry.ProductID == "":
+			return nil, fmt.Errorf("product catalog %s: entry %d has no product_id", path, i)
+		case !entities.ValidCurrencyCode(entry.Currency):
+			return nil, fmt.Errorf("product catalog %s: %s has invalid currency %q", path, entry.ProductID, entry.Currency)
+		case entry.Price < 0:
+			return nil, fmt.Errorf("product catalog %s: %s has a negative price", path, entry.ProductID)
+		}
+		if _, ok := products[entry.ProductID]; ok {
+			return nil, fmt.Errorf("product catalog %s: %s is listed twice", path, entry.ProductID)
+		}
+
+		products[entry.ProductID] = entities.Product{
+			ProductID: entry.ProductID,
+			Price:     entities.NewMoney(entry.Price, entry.Currency),
+			Available: entry.Available == nil || *entry.Available,
+			Category:  entry.Category,
+		}
+	}
+
+	return &StaticCatalog{products: products}, nil
+}
+
+func (c *StaticCatalog) GetProducts(_ context.Context, productIDs []string) (map[string]entities.Product, error) {
+	found := make(map[string]entities.Product, len(productIDs))
+	for _, id := range productIDs {
+		if product, ok := c.products[id]; ok {
+			found[id] = product
+		}
+	}
+	return found, nil
+}
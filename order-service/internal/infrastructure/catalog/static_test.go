@@ -0,0 +1,57 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCatalog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticCatalog_GetProducts(t *testing.T) {
+	catalog, err := NewStaticCatalog(writeCatalog(t, `[
+		{"product_id": "prod1", "price": 1000, "currency": "RUB", "category": "food"},
+		{"product_id": "prod2", "price": 500, "currency": "RUB", "available": false}
+	]`))
+	require.NoError(t, err)
+
+	products, err := catalog.GetProducts(context.Background(), []string{"prod1", "prod2", "missing"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]entities.Product{
+		"prod1": {ProductID: "prod1", Price: entities.NewMoney(1000, "RUB"), Available: true, Category: "food"},
+		"prod2": {ProductID: "prod2", Price: entities.NewMoney(500, "RUB"), Available: false},
+	}, products)
+}
+
+func TestNewStaticCatalog_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":        `{"product_id": "prod1"`,
+		"missing id":       `[{"price": 1000, "currency": "RUB"}]`,
+		"invalid currency": `[{"product_id": "prod1", "price": 1000, "currency": "rub"}]`,
+		"negative price":   `[{"product_id": "prod1", "price": -1, "currency": "RUB"}]`,
+		"duplicate": `[{"product_id": "prod1", "price": 1000, "currency": "RUB"},
+			{"product_id": "prod1", "price": 900, "currency": "RUB"}]`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStaticCatalog(writeCatalog(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewStaticCatalog(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
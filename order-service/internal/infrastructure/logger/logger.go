@@ -1,44 +1,119 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
 )
 
+// Logger writes structured, leveled logs. It embeds *slog.Logger, so Info, Warn
+// and Error take a message followed by key/value pairs. The *Context variants
+// also add the fields stored in the context by WithFields and the current
+// trace and span IDs.
 type Logger struct {
-	infoLog  *log.Logger
-	warnLog  *log.Logger
-	errorLog *log.Logger
+	*slog.Logger
 }
 
-func NewLogger() *Logger {
-	return &Logger{
-		infoLog:  log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile),
-		warnLog:  log.New(os.Stdout, "WARN: ", log.Ldate|log.Ltime|log.Lshortfile),
-		errorLog: log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile),
+// New returns a logger writing to stdout in format ("json" or "text") that
+// drops records below level ("debug", "info", "warn" or "error").
+func New(format, level string) (*Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
 	}
+	return newLogger(os.Stdout, format, lvl)
+}
+
+// NewLogger returns a text logger at info level.
+func NewLogger() *Logger {
+	l, _ := newLogger(os.Stdout, FormatText, slog.LevelInfo)
+	return l
 }
 
-func (l *Logger) Info(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.infoLog.Printf(msg+": %v", args...)
-	} else {
-		l.infoLog.Println(msg)
+func newLogger(w io.Writer, format string, level slog.Level) (*Logger, error) {
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
 	}
+
+	var handler slog.Handler
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+
+	return &Logger{Logger: slog.New(contextHandler{handler})}, nil
 }
 
-func (l *Logger) Warn(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.warnLog.Printf(msg+": %v", args...)
-	} else {
-		l.warnLog.Println(msg)
+// ParseLevel parses a level name such as "debug" or "WARN".
+func ParseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", level)
 	}
+	return lvl, nil
 }
 
-func (l *Logger) Error(msg string, args ...interface{}) {
-	if len(args) > 0 {
-		l.errorLog.Printf(msg+": %v", args...)
-	} else {
-		l.errorLog.Println(msg)
+type fieldsKey struct{}
+
+// WithFields returns a copy of ctx carrying args, given as key/value pairs or
+// slog.Attr values. They are added to every record logged with the context.
+func WithFields(ctx context.Context, args ...any) context.Context {
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+
+	existing := fieldsFrom(ctx)
+	fields := make([]slog.Attr, len(existing), len(existing)+record.NumAttrs())
+	copy(fields, existing)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = append(fields, attr)
+		return true
+	})
+
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+func fieldsFrom(ctx context.Context) []slog.Attr {
+	fields, _ := ctx.Value(fieldsKey{}).([]slog.Attr)
+	return fields
+}
+
+// contextHandler adds the context fields and trace IDs to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(fieldsFrom(ctx)...)
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
 	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
 }
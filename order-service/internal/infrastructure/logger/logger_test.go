@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_JSONKeyValues(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := newLogger(&buf, FormatJSON, slog.LevelInfo)
+	assert.NoError(t, err)
+
+	l.Info("Order created", "order_id", "abc", "items", 2)
+	l.Error("Failed to publish", "error", errors.New("nats down"))
+
+	records := decodeLines(t, &buf)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "INFO", records[0]["level"])
+		assert.Equal(t, "Order created", records[0]["msg"])
+		assert.Equal(t, "abc", records[0]["order_id"])
+		assert.Equal(t, float64(2), records[0]["items"])
+		assert.Equal(t, "nats down", records[1]["error"])
+	}
+}
+
+func TestLogger_MinimumLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := newLogger(&buf, FormatJSON, slog.LevelWarn)
+	assert.NoError(t, err)
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+
+	records := decodeLines(t, &buf)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "warn", records[0]["msg"])
+	}
+}
+
+func TestLogger_ContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := newLogger(&buf, FormatJSON, slog.LevelInfo)
+	assert.NoError(t, err)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	ctx = WithFields(ctx, "request_id", "req-1", "method", "/order.OrderService/GetOrder")
+	child := WithFields(ctx, "user_id", "user123")
+
+	l.InfoContext(child, "handled")
+	l.InfoContext(ctx, "parent")
+
+	records := decodeLines(t, &buf)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "req-1", records[0]["request_id"])
+		assert.Equal(t, "/order.OrderService/GetOrder", records[0]["method"])
+		assert.Equal(t, "user123", records[0]["user_id"])
+		assert.Equal(t, traceID.String(), records[0]["trace_id"])
+		assert.Equal(t, spanID.String(), records[0]["span_id"])
+
+		// Fields added to a derived context do not leak into the parent.
+		assert.NotContains(t, records[1], "user_id")
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	_, err := New("xml", "info")
+	assert.Error(t, err)
+
+	_, err = New(FormatJSON, "verbose")
+	assert.Error(t, err)
+
+	_, err = New(FormatText, "WARN")
+	assert.NoError(t, err)
+}
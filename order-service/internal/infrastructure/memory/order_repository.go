@@ -1,24 +1,50 @@
 package memory
 
 import (
+	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"order-service/internal/domain/entities"
 	"order-service/internal/domain/repositories"
 )
 
+var _ repositories.OrderStore = (*OrderRepositoryMemory)(nil)
+
+// OrderRepositoryMemory keeps orders and their outbox in process memory. It
+// behaves like the persistent repositories and suits local development and
+// tests; everything is lost on restart.
 type OrderRepositoryMemory struct {
 	mu     sync.RWMutex
 	orders map[string]*entities.Order
+	// idempotencyKeys maps user ID and idempotency key to order ID.
+	idempotencyKeys map[idempotencyKey]string
+	// outbox holds undelivered events in the order they were stored.
+	outbox []*outboxEntry
+	// history holds the status changes of each order, oldest first.
+	history map[string][]entities.StatusChange
+}
+
+type idempotencyKey struct {
+	userID string
+	key    string
+}
+
+type outboxEntry struct {
+	record        repositories.OutboxRecord
+	nextAttemptAt time.Time
 }
 
 func NewOrderRepositoryMemory() *OrderRepositoryMemory {
 	return &OrderRepositoryMemory{
-		orders: make(map[string]*entities.Order),
+		orders:          make(map[string]*entities.Order),
+		idempotencyKeys: make(map[idempotencyKey]string),
+		history:         make(map[string][]entities.StatusChange),
 	}
 }
 
-func (r *OrderRepositoryMemory) Create(order *entities.Order) error {
+func (r *OrderRepositoryMemory) Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -26,12 +52,80 @@ func (r *OrderRepositoryMemory) Create(order *entities.Order) error {
 		return repositories.ErrOrderAlreadyExists
 	}
 
-	orderCopy := *order
-	r.orders[order.OrderID] = &orderCopy
+	key := idempotencyKey{userID: order.UserID, key: order.IdempotencyKey}
+	if order.IdempotencyKey != "" {
+		if _, exists := r.idempotencyKeys[key]; exists {
+			return repositories.ErrOrderAlreadyExists
+		}
+		r.idempotencyKeys[key] = order.OrderID
+	}
+
+	r.orders[order.OrderID] = cloneOrder(order)
+	r.history[order.OrderID] = []entities.StatusChange{order.CreationChange()}
+	r.appendEvent(event)
 	return nil
 }
 
-func (r *OrderRepositoryMemory) GetByID(orderID string) (*entities.Order, error) {
+func (r *OrderRepositoryMemory) appendEvent(event *entities.OrderEvent) {
+	eventCopy := *event
+	eventCopy.Order = cloneOrder(event.Order)
+
+	r.outbox = append(r.outbox, &outboxEntry{
+		record:        repositories.OutboxRecord{Event: &eventCopy},
+		nextAttemptAt: event.OccurredAt,
+	})
+}
+
+func (r *OrderRepositoryMemory) FetchPending(ctx context.Context, limit int) ([]*repositories.OutboxRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	records := make([]*repositories.OutboxRecord, 0)
+	for _, entry := range r.outbox {
+		if len(records) == limit {
+			break
+		}
+		if entry.nextAttemptAt.After(now) {
+			continue
+		}
+		recordCopy := entry.record
+		eventCopy := *entry.record.Event
+		eventCopy.Order = cloneOrder(eventCopy.Order)
+		recordCopy.Event = &eventCopy
+		records = append(records, &recordCopy)
+	}
+
+	return records, nil
+}
+
+func (r *OrderRepositoryMemory) MarkDelivered(ctx context.Context, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.outbox {
+		if entry.record.Event.EventID == eventID {
+			r.outbox = append(r.outbox[:i], r.outbox[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *OrderRepositoryMemory) MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.outbox {
+		if entry.record.Event.EventID == eventID {
+			entry.nextAttemptAt = nextAttemptAt
+			entry.record.Attempts++
+		}
+	}
+	return nil
+}
+
+func (r *OrderRepositoryMemory) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -40,11 +134,78 @@ func (r *OrderRepositoryMemory) GetByID(orderID string) (*entities.Order, error)
 		return nil, repositories.ErrOrderNotFound
 	}
 
-	orderCopy := *order
-	return &orderCopy, nil
+	return cloneOrder(order), nil
+}
+
+func (r *OrderRepositoryMemory) GetByIdempotencyKey(ctx context.Context, userID, key string) (*entities.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orderID, exists := r.idempotencyKeys[idempotencyKey{userID: userID, key: key}]
+	if !exists {
+		return nil, repositories.ErrOrderNotFound
+	}
+
+	return cloneOrder(r.orders[orderID]), nil
+}
+
+func (r *OrderRepositoryMemory) UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return repositories.ErrOrderNotFound
+	}
+
+	if order.Version != expectedVersion {
+		return repositories.ErrVersionConflict
+	}
+
+	if !entities.CanTransition(order.Status, change.To) {
+		return repositories.ErrInvalidTransition
+	}
+	if order.Status == change.To {
+		return nil
+	}
+
+	r.history[orderID] = append(r.history[orderID], change)
+	order.Status = change.To
+	order.UpdatedAt = change.ChangedAt
+	order.Version++
+	if event != nil {
+		r.appendEvent(event)
+	}
+	return nil
+}
+
+func (r *OrderRepositoryMemory) Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return repositories.ErrOrderNotFound
+	}
+
+	if order.Version != expectedVersion {
+		return repositories.ErrVersionConflict
+	}
+
+	if !entities.OrderStatus(order.Status).Cancellable() {
+		return repositories.ErrInvalidTransition
+	}
+
+	r.history[orderID] = append(r.history[orderID], cancellation.StatusChange(order.Status, order.Version+1))
+	order.Status = string(entities.OrderStatusCancelled)
+	order.Cancellation = &cancellation
+	order.UpdatedAt = cancellation.CancelledAt
+	order.Version++
+	r.appendEvent(event)
+	return nil
 }
 
-func (r *OrderRepositoryMemory) UpdateStatus(orderID, status string) error {
+func (r *OrderRepositoryMemory) UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -53,6 +214,145 @@ func (r *OrderRepositoryMemory) UpdateStatus(orderID, status string) error {
 		return repositories.ErrOrderNotFound
 	}
 
-	order.Status = status
+	if order.Version != expectedVersion {
+		return repositories.ErrVersionConflict
+	}
+
+	order.ShippingAddress = cloneAddress(update.Address)
+	order.DeliveryMethod = update.DeliveryMethod
+	order.UpdatedAt = update.UpdatedAt
+	order.Version++
+	r.appendEvent(event)
 	return nil
 }
+
+func (r *OrderRepositoryMemory) UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return repositories.ErrOrderNotFound
+	}
+
+	if order.Version != expectedVersion {
+		return repositories.ErrVersionConflict
+	}
+
+	order.Items = cloneItems(update.Items)
+	order.Subtotal = update.Subtotal
+	order.Discounts = append([]entities.Discount(nil), update.Discounts...)
+	order.TaxAmount = update.TaxAmount
+	order.TotalAmount = update.TotalAmount
+	order.UpdatedAt = update.UpdatedAt
+	order.Version++
+	r.appendEvent(event)
+	return nil
+}
+
+func (r *OrderRepositoryMemory) GetHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, exists := r.orders[orderID]; !exists {
+		return nil, repositories.ErrOrderNotFound
+	}
+
+	return append([]entities.StatusChange{}, r.history[orderID]...), nil
+}
+
+func (r *OrderRepositoryMemory) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := make([]*entities.Order, 0)
+	for _, order := range r.orders {
+		if matchesFilter(order, filter) {
+			orders = append(orders, cloneOrder(order))
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return sortsBefore(cursorOf(orders[i]), cursorOf(orders[j]))
+	})
+
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders, nil
+}
+
+// cloneOrder copies order so that callers and the repository never share
+// mutable state.
+func cloneOrder(order *entities.Order) *entities.Order {
+	orderCopy := *order
+	orderCopy.Items = cloneItems(order.Items)
+	orderCopy.Discounts = append([]entities.Discount(nil), order.Discounts...)
+	orderCopy.ShippingAddress = cloneAddress(order.ShippingAddress)
+	if order.Cancellation != nil {
+		cancellation := *order.Cancellation
+		orderCopy.Cancellation = &cancellation
+	}
+	return &orderCopy
+}
+
+func cloneItems(items []entities.Item) []entities.Item {
+	itemsCopy := append([]entities.Item(nil), items...)
+	for i, item := range itemsCopy {
+		if item.Tax != nil {
+			tax := *item.Tax
+			itemsCopy[i].Tax = &tax
+		}
+	}
+	return itemsCopy
+}
+
+func cloneAddress(address *entities.ShippingAddress) *entities.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+	addressCopy := *address
+	return &addressCopy
+}
+
+func matchesFilter(order *entities.Order, filter repositories.OrderFilter) bool {
+	if filter.UserID != "" && order.UserID != filter.UserID {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !containsString(filter.Statuses, order.Status) {
+		return false
+	}
+	if !filter.CreatedFrom.IsZero() && order.CreatedAt.Before(filter.CreatedFrom) {
+		return false
+	}
+	if !filter.CreatedTo.IsZero() && !order.CreatedAt.Before(filter.CreatedTo) {
+		return false
+	}
+	if filter.After != nil && !sortsBefore(*filter.After, cursorOf(order)) {
+		return false
+	}
+	return true
+}
+
+// sortsBefore reports whether a comes strictly before b in List ordering:
+// newest first, ties broken by OrderID descending.
+func sortsBefore(a, b repositories.OrderCursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.OrderID > b.OrderID
+}
+
+func cursorOf(order *entities.Order) repositories.OrderCursor {
+	return repositories.OrderCursor{CreatedAt: order.CreatedAt, OrderID: order.OrderID}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+)
+
+func TestOrderRepositoryMemory_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositories.OrderStore {
+		return NewOrderRepositoryMemory()
+	})
+}
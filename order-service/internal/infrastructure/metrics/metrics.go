@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "order_service"
+
+// Registry holds every collector of the service, together with the Go runtime
+// and process collectors, and is what Handler exposes.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	rpcRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Handled gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	rpcDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	mongoDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "MongoDB repository operation latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	mongoErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_errors_total",
+		Help:      "MongoDB repository operations that failed with a storage error.",
+	}, []string{"operation"})
+
+	eventsPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_published_total",
+		Help:      "Event publish attempts to NATS by subject and result (success or failure).",
+	}, []string{"subject", "result"})
+
+	eventPublishRetries = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_publish_retries_total",
+		Help:      "Retried NATS publish attempts by subject.",
+	}, []string{"subject"})
+
+	ordersCreated = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_created_total",
+		Help:      "Created orders by status and currency.",
+	}, []string{"status", "currency"})
+
+	orderAmount = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_amount_total",
+		Help:      "Sum of created order totals in major currency units.",
+	}, []string{"currency"})
+
+	orderStatusChanges = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "order_status_changes_total",
+		Help:      "Order status transitions by source and target status.",
+	}, []string{"from", "to"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// UnaryServerInterceptor records latency and status code of every unary RPC.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		rpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
+
+// ObserveMongoOperation records one repository operation. failed should be
+// false for expected outcomes such as a missing order.
+func ObserveMongoOperation(operation string, duration time.Duration, failed bool) {
+	mongoDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if failed {
+		mongoErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+func EventPublished(subject string) {
+	eventsPublished.WithLabelValues(subject, "success").Inc()
+}
+
+func EventPublishFailed(subject string) {
+	eventsPublished.WithLabelValues(subject, "failure").Inc()
+}
+
+func EventPublishRetried(subject string) {
+	eventPublishRetries.WithLabelValues(subject).Inc()
+}
+
+// OrderMetrics records business metrics on behalf of the use case.
+type OrderMetrics struct{}
+
+func (OrderMetrics) OrderCreated(order *entities.Order) {
+	ordersCreated.WithLabelValues(order.Status, order.Currency).Inc()
+	orderAmount.WithLabelValues(order.Currency).Add(order.TotalAmount.Float())
+}
+
+func (OrderMetrics) OrderStatusChanged(order *entities.Order, previousStatus string) {
+	orderStatusChanges.WithLabelValues(previousStatus, order.Status).Inc()
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/GetOrder"}
+
+	before := testutil.ToFloat64(rpcRequests.WithLabelValues(info.FullMethod, "NotFound"))
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "order not found")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(rpcRequests.WithLabelValues(info.FullMethod, "NotFound")))
+}
+
+func TestObserveMongoOperation(t *testing.T) {
+	before := testutil.ToFloat64(mongoErrors.WithLabelValues("test_op"))
+
+	ObserveMongoOperation("test_op", time.Millisecond, false)
+	assert.Equal(t, before, testutil.ToFloat64(mongoErrors.WithLabelValues("test_op")))
+
+	ObserveMongoOperation("test_op", time.Millisecond, true)
+	assert.Equal(t, before+1, testutil.ToFloat64(mongoErrors.WithLabelValues("test_op")))
+}
+
+func TestOrderMetrics(t *testing.T) {
+	order := &entities.Order{
+		Currency:    "EUR",
+		TotalAmount: entities.NewMoney(1250, "EUR"),
+		Status:      string(entities.OrderStatusPending),
+	}
+	created := testutil.ToFloat64(ordersCreated.WithLabelValues("PENDING", "EUR"))
+	amount := testutil.ToFloat64(orderAmount.WithLabelValues("EUR"))
+	changes := testutil.ToFloat64(orderStatusChanges.WithLabelValues("PENDING", "PAID"))
+
+	OrderMetrics{}.OrderCreated(order)
+	order.Status = string(entities.OrderStatusPaid)
+	OrderMetrics{}.OrderStatusChanged(order, string(entities.OrderStatusPending))
+
+	assert.Equal(t, created+1, testutil.ToFloat64(ordersCreated.WithLabelValues("PENDING", "EUR")))
+	assert.InDelta(t, amount+12.5, testutil.ToFloat64(orderAmount.WithLabelValues("EUR")), 1e-9)
+	assert.Equal(t, changes+1, testutil.ToFloat64(orderStatusChanges.WithLabelValues("PENDING", "PAID")))
+}
+
+func TestHandler(t *testing.T) {
+	EventPublished("order.created")
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `order_service_events_published_total{result="success",subject="order.created"}`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,40 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+	"order-service/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderRepositoryMongo_Conformance runs against the MongoDB replica set at
+// MONGO_TEST_URI, giving every test its own database.
+func TestOrderRepositoryMongo_Conformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set")
+	}
+
+	repositorytest.Run(t, func(t *testing.T) repositories.OrderStore {
+		dbName := fmt.Sprintf("orders_test_%d", time.Now().UnixNano())
+
+		repo, err := NewOrderRepositoryMongo(uri, dbName, logger.NewLogger())
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			repo.client.Database(dbName).Drop(ctx)
+			repo.Close()
+		})
+
+		return repo
+	})
+}
@@ -0,0 +1,78 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain/entities"
+)
+
+func createHistoryIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		// Each version of an order is produced by exactly one change.
+		Keys: bson.D{
+			{Key: "order_id", Value: 1},
+			{Key: "version", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *OrderRepositoryMongo) GetHistory(ctx context.Context, orderID string) (_ []entities.StatusChange, err error) {
+	ctx, end := startOperation(ctx, "get_history")
+	defer end(&err)
+
+	cursor, err := r.history.Find(ctx, bson.M{"order_id": orderID},
+		options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []StatusChangeDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode status history: %w", err)
+	}
+
+	// Orders created before the history was kept have no entries.
+	if len(docs) == 0 {
+		if _, err := r.GetByID(ctx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	history := make([]entities.StatusChange, len(docs))
+	for i, doc := range docs {
+		history[i] = entities.StatusChange{
+			From:      doc.From,
+			To:        doc.To,
+			ChangedAt: doc.ChangedAt,
+			Actor:     doc.Actor,
+			Reason:    doc.Reason,
+			Version:   doc.Version,
+		}
+	}
+
+	return history, nil
+}
+
+func (r *OrderRepositoryMongo) insertStatusChange(ctx context.Context, orderID string, change entities.StatusChange) error {
+	_, err := r.history.InsertOne(ctx, &StatusChangeDocument{
+		OrderID:   orderID,
+		Version:   change.Version,
+		From:      change.From,
+		To:        change.To,
+		ChangedAt: change.ChangedAt,
+		Actor:     change.Actor,
+		Reason:    change.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert status change: %w", err)
+	}
+	return nil
+}
@@ -6,18 +6,102 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// OrderDocument is the stored form of an order. Version is absent on documents
+// written before versioning was introduced and decodes as 0; UpdatedAt,
+// SubtotalMinor, the tax and the shipping fields are absent on documents
+// written before they were introduced.
+//
+// Amounts are stored as int64 minor units of Currency. Documents written before
+// that have no currency and keep amounts as float64 in total_amount and price;
+// they are read as DefaultCurrency.
 type OrderDocument struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	OrderID     string             `bson:"order_id"`
-	UserID      string             `bson:"user_id"`
-	Items       []ItemDocument     `bson:"items"`
-	TotalAmount float64            `bson:"total_amount"`
-	Status      string             `bson:"status"`
-	CreatedAt   time.Time          `bson:"created_at"`
+	ID             primitive.ObjectID    `bson:"_id,omitempty"`
+	OrderID        string                `bson:"order_id"`
+	UserID         string                `bson:"user_id"`
+	Items          []ItemDocument        `bson:"items"`
+	Currency       string                `bson:"currency,omitempty"`
+	SubtotalMinor  int64                 `bson:"subtotal_minor,omitempty"`
+	PromoCode      string                `bson:"promo_code,omitempty"`
+	Discounts      []DiscountDocument    `bson:"discounts,omitempty"`
+	Region         string                `bson:"region,omitempty"`
+	TaxMinor       int64                 `bson:"tax_minor,omitempty"`
+	TotalMinor     int64                 `bson:"total_minor"`
+	TotalAmount    float64               `bson:"total_amount,omitempty"`
+	Status         string                `bson:"status"`
+	CreatedAt      time.Time             `bson:"created_at"`
+	UpdatedAt      time.Time             `bson:"updated_at,omitempty"`
+	Version        int64                 `bson:"version"`
+	IdempotencyKey string                `bson:"idempotency_key,omitempty"`
+	RequestHash    string                `bson:"request_hash,omitempty"`
+	Cancellation   *CancellationDocument `bson:"cancellation,omitempty"`
+
+	ShippingAddress *ShippingAddressDocument `bson:"shipping_address,omitempty"`
+	DeliveryMethod  string                   `bson:"delivery_method,omitempty"`
+}
+
+type ShippingAddressDocument struct {
+	RecipientName string `bson:"recipient_name"`
+	Phone         string `bson:"phone,omitempty"`
+	Line1         string `bson:"line1"`
+	Line2         string `bson:"line2,omitempty"`
+	City          string `bson:"city"`
+	State         string `bson:"state,omitempty"`
+	PostalCode    string `bson:"postal_code"`
+	CountryCode   string `bson:"country_code"`
+}
+
+type DiscountDocument struct {
+	PromoCode   string `bson:"promo_code"`
+	Description string `bson:"description"`
+	AmountMinor int64  `bson:"amount_minor"`
+}
+
+type CancellationDocument struct {
+	CancelledAt time.Time `bson:"cancelled_at"`
+	Reason      string    `bson:"reason"`
+	Comment     string    `bson:"comment,omitempty"`
+	CancelledBy string    `bson:"cancelled_by"`
+}
+
+// StatusChangeDocument is an entry of the append-only order_status_history
+// collection.
+type StatusChangeDocument struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OrderID   string             `bson:"order_id"`
+	Version   int64              `bson:"version"`
+	From      string             `bson:"from,omitempty"`
+	To        string             `bson:"to"`
+	ChangedAt time.Time          `bson:"changed_at"`
+	Actor     string             `bson:"actor,omitempty"`
+	Reason    string             `bson:"reason,omitempty"`
 }
 
 type ItemDocument struct {
-	ProductID string  `bson:"product_id"`
-	Quantity  int     `bson:"quantity"`
-	Price     float64 `bson:"price"`
+	ProductID  string       `bson:"product_id"`
+	Quantity   int          `bson:"quantity"`
+	PriceMinor int64        `bson:"price_minor"`
+	Price      float64      `bson:"price,omitempty"`
+	Category   string       `bson:"category,omitempty"`
+	Tax        *TaxDocument `bson:"tax,omitempty"`
+}
+
+type TaxDocument struct {
+	RateBasisPoints int64 `bson:"rate_basis_points"`
+	Inclusive       bool  `bson:"inclusive"`
+	AmountMinor     int64 `bson:"amount_minor"`
+}
+
+type OutboxDocument struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	EventID        string             `bson:"event_id"`
+	EventType      string             `bson:"event_type"`
+	OrderID        string             `bson:"order_id"`
+	Order          OrderDocument      `bson:"order"`
+	PreviousStatus string             `bson:"previous_status,omitempty"`
+	OccurredAt     time.Time          `bson:"occurred_at"`
+	Attempts       int                `bson:"attempts"`
+	NextAttemptAt  time.Time          `bson:"next_attempt_at"`
+	DeliveredAt    *time.Time         `bson:"delivered_at,omitempty"`
+	LastError      string             `bson:"last_error,omitempty"`
+	TraceContext   map[string]string  `bson:"trace_context,omitempty"`
 }
@@ -2,21 +2,32 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"order-service/internal/domain/entities"
 	"order-service/internal/domain/repositories"
 	"order-service/internal/infrastructure/logger"
+	"order-service/internal/infrastructure/metrics"
 )
 
+var tracer = otel.Tracer("order-service/internal/infrastructure/mongodb")
+
 type OrderRepositoryMongo struct {
 	client     *mongo.Client
 	collection *mongo.Collection
+	outbox     *mongo.Collection
+	history    *mongo.Collection
 	logger     *logger.Logger
 }
 
@@ -36,17 +47,59 @@ func NewOrderRepositoryMongo(uri, dbName string, logger *logger.Logger) (*OrderR
 
 	collection := client.Database(dbName).Collection("orders")
 
-	_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys:    bson.D{{Key: "order_id", Value: 1}},
-		Options: options.Index().SetUnique(true),
+	_, err = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// Keys are scoped per user and only present on orders created with one.
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "idempotency_key", Value: 1},
+			},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+		},
+		{
+			// Supports List filtered by user, optionally narrowed by status.
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: -1},
+				{Key: "order_id", Value: -1},
+			},
+		},
+		{
+			// Supports List filtered by status without a user.
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "created_at", Value: -1},
+				{Key: "order_id", Value: -1},
+			},
+		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create index: %w", err)
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	outbox := client.Database(dbName).Collection("outbox")
+
+	if err := createOutboxIndexes(ctx, outbox); err != nil {
+		return nil, fmt.Errorf("failed to create outbox indexes: %w", err)
+	}
+
+	history := client.Database(dbName).Collection("order_status_history")
+
+	if err := createHistoryIndexes(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to create status history indexes: %w", err)
 	}
 
 	return &OrderRepositoryMongo{
 		client:     client,
 		collection: collection,
+		outbox:     outbox,
+		history:    history,
 		logger:     logger,
 	}, nil
 }
@@ -57,10 +110,27 @@ func (r *OrderRepositoryMongo) Close() error {
 	return r.client.Disconnect(ctx)
 }
 
-func (r *OrderRepositoryMongo) Create(ctx context.Context, order *entities.Order) error {
-	doc := toOrderDocument(order)
+// Ping checks that the primary is reachable; used by health checks.
+func (r *OrderRepositoryMongo) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx, readpref.Primary())
+}
+
+// Create inserts the order, its first status history entry and its outbox
+// event in a single transaction, so the event is stored if and only if the
+// order is. Transactions require MongoDB to run as a replica set.
+func (r *OrderRepositoryMongo) Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) (err error) {
+	ctx, end := startOperation(ctx, "create")
+	defer end(&err)
 
-	_, err := r.collection.InsertOne(ctx, doc)
+	err = r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(sc, toOrderDocument(order)); err != nil {
+			return err
+		}
+		if err := r.insertStatusChange(sc, order.OrderID, order.CreationChange()); err != nil {
+			return err
+		}
+		return r.insertOutboxEvent(sc, event)
+	})
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
 			return repositories.ErrOrderAlreadyExists
@@ -71,9 +141,12 @@ func (r *OrderRepositoryMongo) Create(ctx context.Context, order *entities.Order
 	return nil
 }
 
-func (r *OrderRepositoryMongo) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
+func (r *OrderRepositoryMongo) GetByID(ctx context.Context, orderID string) (_ *entities.Order, err error) {
+	ctx, end := startOperation(ctx, "get_by_id")
+	defer end(&err)
+
 	var doc OrderDocument
-	err := r.collection.FindOne(ctx, bson.M{"order_id": orderID}).Decode(&doc)
+	err = r.collection.FindOne(ctx, bson.M{"order_id": orderID}).Decode(&doc)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, repositories.ErrOrderNotFound
@@ -84,74 +157,527 @@ func (r *OrderRepositoryMongo) GetByID(ctx context.Context, orderID string) (*en
 	return toOrderEntity(&doc), nil
 }
 
-func (r *OrderRepositoryMongo) UpdateStatus(ctx context.Context, orderID, status string) error {
-	result, err := r.collection.UpdateOne(
-		ctx,
-		bson.M{"order_id": orderID},
-		bson.M{"$set": bson.M{"status": status}},
-	)
+func (r *OrderRepositoryMongo) GetByIdempotencyKey(ctx context.Context, userID, key string) (_ *entities.Order, err error) {
+	ctx, end := startOperation(ctx, "get_by_idempotency_key")
+	defer end(&err)
+
+	var doc OrderDocument
+	err = r.collection.FindOne(ctx, bson.M{"user_id": userID, "idempotency_key": key}).Decode(&doc)
 	if err != nil {
-		return fmt.Errorf("failed to update order: %w", err)
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to find order: %w", err)
 	}
 
-	if result.MatchedCount == 0 {
-		return repositories.ErrOrderNotFound
+	return toOrderEntity(&doc), nil
+}
+
+func (r *OrderRepositoryMongo) UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) (err error) {
+	ctx, end := startOperation(ctx, "update_status")
+	defer end(&err)
+
+	var version interface{} = expectedVersion
+	if expectedVersion == 0 {
+		// Documents written before versioning have no version field.
+		version = bson.M{"$in": bson.A{0, nil}}
 	}
 
-	if result.ModifiedCount == 0 && result.MatchedCount > 0 {
-		r.logger.Info("Order status already set to requested value",
-			"order_id", orderID,
-			"status", status,
-			"matched_count", result.MatchedCount,
-			"modified_count", result.ModifiedCount)
-	} else {
-		r.logger.Info("Order status updated successfully",
-			"order_id", orderID,
-			"new_status", status,
-			"matched_count", result.MatchedCount,
-			"modified_count", result.ModifiedCount)
+	err = r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		// The order as it was before the update tells whether the status
+		// actually changed and so whether to record it in the history.
+		var previous OrderDocument
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			bson.M{
+				"order_id": orderID,
+				"status":   bson.M{"$in": entities.SourceStatuses(change.To), "$ne": change.To},
+				"version":  version,
+			},
+			bson.M{
+				"$set": bson.M{"status": change.To, "updated_at": change.ChangedAt},
+				"$inc": bson.M{"version": 1},
+			},
+			options.FindOneAndUpdate().
+				SetReturnDocument(options.Before).
+				SetProjection(bson.M{"status": 1}),
+		).Decode(&previous)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			current, err := r.GetByID(sc, orderID)
+			if err != nil {
+				return err
+			}
+			if current.Version != expectedVersion {
+				return repositories.ErrVersionConflict
+			}
+			if current.Status == change.To {
+				// Keeping the status changes nothing.
+				return nil
+			}
+			return repositories.ErrInvalidTransition
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		if previous.Status != change.To {
+			if err := r.insertStatusChange(sc, orderID, change); err != nil {
+				return err
+			}
+		}
+		if event == nil {
+			return nil
+		}
+		return r.insertOutboxEvent(sc, event)
+	})
+	if err != nil {
+		return err
 	}
 
+	r.logger.DebugContext(ctx, "Order status updated successfully",
+		"order_id", orderID,
+		"new_status", change.To,
+		"version", expectedVersion+1)
+
 	return nil
 }
 
+func (r *OrderRepositoryMongo) Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) (err error) {
+	ctx, end := startOperation(ctx, "cancel")
+	defer end(&err)
+
+	var version interface{} = expectedVersion
+	if expectedVersion == 0 {
+		version = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		// The order as it was before the update supplies the status the
+		// history entry starts from.
+		var previous OrderDocument
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			bson.M{
+				"order_id": orderID,
+				"status":   bson.M{"$in": entities.CancellableStatuses()},
+				"version":  version,
+			},
+			bson.M{
+				"$set": bson.M{
+					"status":       string(entities.OrderStatusCancelled),
+					"cancellation": toCancellationDocument(&cancellation),
+					"updated_at":   cancellation.CancelledAt,
+				},
+				"$inc": bson.M{"version": 1},
+			},
+			options.FindOneAndUpdate().
+				SetReturnDocument(options.Before).
+				SetProjection(bson.M{"status": 1}),
+		).Decode(&previous)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			current, err := r.GetByID(sc, orderID)
+			if err != nil {
+				return err
+			}
+			if current.Version != expectedVersion {
+				return repositories.ErrVersionConflict
+			}
+			return repositories.ErrInvalidTransition
+		}
+		if err != nil {
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+
+		if err := r.insertStatusChange(sc, orderID, cancellation.StatusChange(previous.Status, expectedVersion+1)); err != nil {
+			return err
+		}
+		return r.insertOutboxEvent(sc, event)
+	})
+}
+
+func (r *OrderRepositoryMongo) UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) (err error) {
+	ctx, end := startOperation(ctx, "update_shipping")
+	defer end(&err)
+
+	var version interface{} = expectedVersion
+	if expectedVersion == 0 {
+		version = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(
+			sc,
+			bson.M{"order_id": orderID, "version": version},
+			bson.M{
+				"$set": bson.M{
+					"shipping_address": toShippingAddressDocument(update.Address),
+					"delivery_method":  string(update.DeliveryMethod),
+					"updated_at":       update.UpdatedAt,
+				},
+				"$inc": bson.M{"version": 1},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update shipping details: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			if _, err := r.GetByID(sc, orderID); err != nil {
+				return err
+			}
+			return repositories.ErrVersionConflict
+		}
+
+		return r.insertOutboxEvent(sc, event)
+	})
+}
+
+// UpdateItems rewrites the items and amounts of the order in a single update,
+// so readers never see items that do not add up to the stored total. Orders
+// stored before amounts were kept in minor units are converted on the way:
+// their currency is set and the float total is dropped.
+func (r *OrderRepositoryMongo) UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) (err error) {
+	ctx, end := startOperation(ctx, "update_items")
+	defer end(&err)
+
+	var version interface{} = expectedVersion
+	if expectedVersion == 0 {
+		version = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(
+			sc,
+			bson.M{"order_id": orderID, "version": version},
+			bson.M{
+				"$set": bson.M{
+					"items":          toItemDocuments(update.Items),
+					"currency":       update.TotalAmount.Currency,
+					"subtotal_minor": update.Subtotal.Amount,
+					"discounts":      toDiscountDocuments(update.Discounts),
+					"tax_minor":      update.TaxAmount.Amount,
+					"total_minor":    update.TotalAmount.Amount,
+					"updated_at":     update.UpdatedAt,
+				},
+				"$unset": bson.M{"total_amount": ""},
+				"$inc":   bson.M{"version": 1},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update order items: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			if _, err := r.GetByID(sc, orderID); err != nil {
+				return err
+			}
+			return repositories.ErrVersionConflict
+		}
+
+		return r.insertOutboxEvent(sc, event)
+	})
+}
+
+func (r *OrderRepositoryMongo) List(ctx context.Context, filter repositories.OrderFilter) (_ []*entities.Order, err error) {
+	ctx, end := startOperation(ctx, "list")
+	defer end(&err)
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "created_at", Value: -1},
+		{Key: "order_id", Value: -1},
+	})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, toListQuery(filter), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []OrderDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode orders: %w", err)
+	}
+
+	orders := make([]*entities.Order, len(docs))
+	for i := range docs {
+		orders[i] = toOrderEntity(&docs[i])
+	}
+
+	return orders, nil
+}
+
+func toListQuery(filter repositories.OrderFilter) bson.M {
+	query := bson.M{}
+
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	createdAt := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		createdAt["$gte"] = filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		createdAt["$lt"] = filter.CreatedTo
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	if filter.After != nil {
+		query["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": filter.After.CreatedAt}},
+			bson.M{
+				"created_at": filter.After.CreatedAt,
+				"order_id":   bson.M{"$lt": filter.After.OrderID},
+			},
+		}
+	}
+
+	return query
+}
+
+// startOperation starts a child span for a repository operation and returns
+// a function that ends it and records the operation's metrics. No span is
+// started without a parent, so background polling does not create traces.
+// Domain outcomes such as ErrOrderNotFound are not counted as failures.
+func startOperation(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+
+	var span trace.Span
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		ctx, span = tracer.Start(ctx, "mongodb."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNameMongoDB, semconv.DBOperationName(operation)))
+	}
+
+	return ctx, func(err *error) {
+		var repoErr *repositories.RepositoryError
+		failed := *err != nil && !errors.As(*err, &repoErr)
+
+		if span != nil {
+			if failed {
+				span.RecordError(*err)
+				span.SetStatus(codes.Error, (*err).Error())
+			}
+			span.End()
+		}
+
+		metrics.ObserveMongoOperation(operation, time.Since(start), failed)
+	}
+}
+
+// withTransaction runs fn in a MongoDB transaction, retrying it on transient errors.
+func (r *OrderRepositoryMongo) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
 func toOrderDocument(order *entities.Order) *OrderDocument {
 	doc := &OrderDocument{
-		OrderID:     order.OrderID,
-		UserID:      order.UserID,
-		TotalAmount: order.TotalAmount,
-		Status:      order.Status,
-		CreatedAt:   order.CreatedAt,
-		Items:       make([]ItemDocument, len(order.Items)),
+		OrderID:         order.OrderID,
+		UserID:          order.UserID,
+		Currency:        order.Currency,
+		SubtotalMinor:   order.Subtotal.Amount,
+		PromoCode:       order.PromoCode,
+		Region:          order.Region,
+		TaxMinor:        order.TaxAmount.Amount,
+		TotalMinor:      order.TotalAmount.Amount,
+		ShippingAddress: toShippingAddressDocument(order.ShippingAddress),
+		DeliveryMethod:  string(order.DeliveryMethod),
+		Status:          order.Status,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+		Version:         order.Version,
+		Items:           toItemDocuments(order.Items),
+		Discounts:       toDiscountDocuments(order.Discounts),
+		IdempotencyKey:  order.IdempotencyKey,
+		RequestHash:     order.RequestHash,
+		Cancellation:    toCancellationDocument(order.Cancellation),
 	}
+	return doc
+}
 
-	for i, item := range order.Items {
-		doc.Items[i] = ItemDocument{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+func toItemDocuments(items []entities.Item) []ItemDocument {
+	docs := make([]ItemDocument, len(items))
+	for i, item := range items {
+		docs[i] = ItemDocument{
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			PriceMinor: item.Price.Amount,
+			Category:   item.Category,
+		}
+		if item.Tax != nil {
+			docs[i].Tax = &TaxDocument{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				AmountMinor:     item.Tax.Amount.Amount,
+			}
 		}
 	}
+	return docs
+}
 
-	return doc
+func toDiscountDocuments(discounts []entities.Discount) []DiscountDocument {
+	var docs []DiscountDocument
+	for _, discount := range discounts {
+		docs = append(docs, DiscountDocument{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			AmountMinor: discount.Amount.Amount,
+		})
+	}
+	return docs
 }
 
 func toOrderEntity(doc *OrderDocument) *entities.Order {
+	legacy := doc.Currency == ""
+	currency := doc.Currency
+	if legacy {
+		currency = entities.DefaultCurrency
+	}
+
 	items := make([]entities.Item, len(doc.Items))
 	for i, item := range doc.Items {
+		price := entities.NewMoney(item.PriceMinor, currency)
+		if legacy {
+			price = legacyMoney(item.Price)
+		}
 		items[i] = entities.Item{
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
-			Price:     item.Price,
+			Price:     price,
+			Category:  item.Category,
+		}
+		if item.Tax != nil {
+			items[i].Tax = &entities.ItemTax{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				Amount:          entities.NewMoney(item.Tax.AmountMinor, currency),
+			}
 		}
 	}
 
+	total := entities.NewMoney(doc.TotalMinor, currency)
+	if legacy {
+		total = legacyMoney(doc.TotalAmount)
+	}
+
+	var discounts []entities.Discount
+	for _, discount := range doc.Discounts {
+		discounts = append(discounts, entities.Discount{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			Amount:      entities.NewMoney(discount.AmountMinor, currency),
+		})
+	}
+
+	// Orders written before discounts existed were never discounted.
+	subtotal := entities.NewMoney(doc.SubtotalMinor, currency)
+	if doc.SubtotalMinor == 0 && len(doc.Discounts) == 0 {
+		subtotal = total
+	}
+
+	updatedAt := doc.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = doc.CreatedAt
+	}
+
 	return &entities.Order{
-		OrderID:     doc.OrderID,
-		UserID:      doc.UserID,
-		Items:       items,
-		TotalAmount: doc.TotalAmount,
-		Status:      doc.Status,
-		CreatedAt:   doc.CreatedAt,
+		OrderID:         doc.OrderID,
+		UserID:          doc.UserID,
+		Items:           items,
+		Currency:        currency,
+		Subtotal:        subtotal,
+		PromoCode:       doc.PromoCode,
+		Discounts:       discounts,
+		Region:          doc.Region,
+		TaxAmount:       entities.NewMoney(doc.TaxMinor, currency),
+		TotalAmount:     total,
+		ShippingAddress: toShippingAddressEntity(doc.ShippingAddress),
+		DeliveryMethod:  entities.DeliveryMethod(doc.DeliveryMethod),
+		Status:          doc.Status,
+		CreatedAt:       doc.CreatedAt,
+		UpdatedAt:       updatedAt,
+		Version:         doc.Version,
+		IdempotencyKey:  doc.IdempotencyKey,
+		RequestHash:     doc.RequestHash,
+		Cancellation:    toCancellationEntity(doc.Cancellation),
+	}
+}
+
+func toShippingAddressDocument(address *entities.ShippingAddress) *ShippingAddressDocument {
+	if address == nil {
+		return nil
+	}
+	return &ShippingAddressDocument{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		State:         address.State,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
 	}
 }
+
+func toShippingAddressEntity(doc *ShippingAddressDocument) *entities.ShippingAddress {
+	if doc == nil {
+		return nil
+	}
+	return &entities.ShippingAddress{
+		RecipientName: doc.RecipientName,
+		Phone:         doc.Phone,
+		Line1:         doc.Line1,
+		Line2:         doc.Line2,
+		City:          doc.City,
+		State:         doc.State,
+		PostalCode:    doc.PostalCode,
+		CountryCode:   doc.CountryCode,
+	}
+}
+
+func toCancellationDocument(cancellation *entities.Cancellation) *CancellationDocument {
+	if cancellation == nil {
+		return nil
+	}
+	return &CancellationDocument{
+		CancelledAt: cancellation.CancelledAt,
+		Reason:      string(cancellation.Reason),
+		Comment:     cancellation.Comment,
+		CancelledBy: cancellation.CancelledBy,
+	}
+}
+
+func toCancellationEntity(doc *CancellationDocument) *entities.Cancellation {
+	if doc == nil {
+		return nil
+	}
+	return &entities.Cancellation{
+		CancelledAt: doc.CancelledAt,
+		Reason:      entities.CancelReason(doc.Reason),
+		Comment:     doc.Comment,
+		CancelledBy: doc.CancelledBy,
+	}
+}
+
+// legacyMoney converts a float64 amount from a document written before Money was
+// introduced. Such amounts were always small enough to fit, so the overflow
+// error cannot occur.
+func legacyMoney(amount float64) entities.Money {
+	money, _ := entities.MoneyFromFloat(amount, entities.DefaultCurrency)
+	return money
+}
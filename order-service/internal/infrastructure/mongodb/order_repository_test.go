@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderDocument_RoundTrip(t *testing.T) {
+	order := &entities.Order{
+		OrderID: "order-1",
+		UserID:  "user123",
+		Items: []entities.Item{
+			{
+				ProductID: "prod1", Quantity: 2, Price: entities.NewMoney(1050, "EUR"), Category: "food",
+				Tax: &entities.ItemTax{RateBasisPoints: 1000, Inclusive: true, Amount: entities.NewMoney(172, "EUR")},
+			},
+		},
+		Currency:  "EUR",
+		Subtotal:  entities.NewMoney(2100, "EUR"),
+		PromoCode: "SALE10",
+		Discounts: []entities.Discount{
+			{PromoCode: "SALE10", Description: "10% off", Amount: entities.NewMoney(210, "EUR")},
+		},
+		Region:      "DE",
+		TaxAmount:   entities.NewMoney(172, "EUR"),
+		TotalAmount: entities.NewMoney(1890, "EUR"),
+		ShippingAddress: &entities.ShippingAddress{
+			RecipientName: "Erika Mustermann",
+			Line1:         "Heidestraße 17",
+			City:          "Berlin",
+			PostalCode:    "10557",
+			CountryCode:   "DE",
+		},
+		DeliveryMethod: entities.DeliveryMethodCourier,
+		Status:         "PENDING",
+		CreatedAt:      time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:      time.Date(2025, 1, 2, 4, 0, 0, 0, time.UTC),
+		Version:        1,
+	}
+
+	assert.Equal(t, order, toOrderEntity(toOrderDocument(order)))
+}
+
+func TestToOrderEntity_MissingUpdatedAt(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := &OrderDocument{OrderID: "order-1", Currency: "EUR", Status: "PENDING", CreatedAt: createdAt}
+
+	assert.Equal(t, createdAt, toOrderEntity(doc).UpdatedAt)
+}
+
+func TestToOrderEntity_MissingSubtotal(t *testing.T) {
+	doc := &OrderDocument{OrderID: "order-1", Currency: "EUR", TotalMinor: 2100, Status: "PENDING"}
+
+	assert.Equal(t, entities.NewMoney(2100, "EUR"), toOrderEntity(doc).Subtotal)
+}
+
+func TestToOrderEntity_LegacyFloatAmounts(t *testing.T) {
+	doc := &OrderDocument{
+		OrderID: "order-1",
+		UserID:  "user123",
+		Items: []ItemDocument{
+			{ProductID: "prod1", Quantity: 3, Price: 0.1},
+			{ProductID: "prod2", Quantity: 1, Price: 19.99},
+		},
+		TotalAmount: 20.290000000000003,
+		Status:      "PAID",
+	}
+
+	order := toOrderEntity(doc)
+
+	assert.Equal(t, entities.DefaultCurrency, order.Currency)
+	assert.Equal(t, entities.NewMoney(10, entities.DefaultCurrency), order.Items[0].Price)
+	assert.Equal(t, entities.NewMoney(1999, entities.DefaultCurrency), order.Items[1].Price)
+	assert.Equal(t, entities.NewMoney(2029, entities.DefaultCurrency), order.TotalAmount)
+}
@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+// deliveredOutboxTTL is how long delivered events are kept before MongoDB removes them.
+const deliveredOutboxTTL = 7 * 24 * time.Hour
+
+func createOutboxIndexes(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// Supports FetchPending.
+			Keys: bson.D{
+				{Key: "delivered_at", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+		},
+		{
+			Keys:    bson.D{{Key: "delivered_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(deliveredOutboxTTL.Seconds())),
+		},
+	})
+	return err
+}
+
+func (r *OrderRepositoryMongo) FetchPending(ctx context.Context, limit int) (_ []*repositories.OutboxRecord, err error) {
+	ctx, end := startOperation(ctx, "outbox_fetch_pending")
+	defer end(&err)
+
+	cursor, err := r.outbox.Find(
+		ctx,
+		bson.M{
+			"delivered_at":    nil,
+			"next_attempt_at": bson.M{"$lte": time.Now()},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "occurred_at", Value: 1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []OutboxDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode pending events: %w", err)
+	}
+
+	records := make([]*repositories.OutboxRecord, len(docs))
+	for i := range docs {
+		records[i] = toOutboxRecord(&docs[i])
+	}
+
+	return records, nil
+}
+
+func (r *OrderRepositoryMongo) MarkDelivered(ctx context.Context, eventID string) (err error) {
+	ctx, end := startOperation(ctx, "outbox_mark_delivered")
+	defer end(&err)
+
+	_, err = r.outbox.UpdateOne(
+		ctx,
+		bson.M{"event_id": eventID},
+		bson.M{
+			"$set":   bson.M{"delivered_at": time.Now()},
+			"$inc":   bson.M{"attempts": 1},
+			"$unset": bson.M{"last_error": ""},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark event delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *OrderRepositoryMongo) MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) (err error) {
+	ctx, end := startOperation(ctx, "outbox_mark_failed")
+	defer end(&err)
+
+	_, err = r.outbox.UpdateOne(
+		ctx,
+		bson.M{"event_id": eventID},
+		bson.M{
+			"$set": bson.M{
+				"next_attempt_at": nextAttemptAt,
+				"last_error":      cause.Error(),
+			},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+	return nil
+}
+
+func (r *OrderRepositoryMongo) insertOutboxEvent(ctx context.Context, event *entities.OrderEvent) error {
+	if _, err := r.outbox.InsertOne(ctx, toOutboxDocument(event)); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+func toOutboxDocument(event *entities.OrderEvent) *OutboxDocument {
+	return &OutboxDocument{
+		EventID:        event.EventID,
+		EventType:      string(event.Type),
+		OrderID:        event.Order.OrderID,
+		Order:          *toOrderDocument(event.Order),
+		PreviousStatus: event.PreviousStatus,
+		OccurredAt:     event.OccurredAt,
+		NextAttemptAt:  event.OccurredAt,
+		TraceContext:   event.TraceContext,
+	}
+}
+
+func toOutboxRecord(doc *OutboxDocument) *repositories.OutboxRecord {
+	return &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID:        doc.EventID,
+			Type:           entities.OrderEventType(doc.EventType),
+			Order:          toOrderEntity(&doc.Order),
+			PreviousStatus: doc.PreviousStatus,
+			OccurredAt:     doc.OccurredAt,
+			TraceContext:   doc.TraceContext,
+		},
+		Attempts: doc.Attempts,
+	}
+}
@@ -0,0 +1,166 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/infrastructure/logger"
+	"order-service/internal/usecase"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	relayPollInterval   = 1 * time.Second
+	relayBatchSize      = 100
+	relayPublishTimeout = 10 * time.Second
+	relayMinBackoff     = 1 * time.Second
+	relayMaxBackoff     = 5 * time.Minute
+)
+
+// OutboxRelay delivers events recorded in the outbox to the publisher. An event
+// is marked delivered only after the publisher accepted it, so every event is
+// published at least once; consumers must tolerate duplicates.
+type OutboxRelay struct {
+	outbox       repositories.OutboxRepository
+	publisher    usecase.NatsPublisher
+	logger       *logger.Logger
+	pollInterval time.Duration
+	batchSize    int
+}
+
+func NewOutboxRelay(outbox repositories.OutboxRepository, publisher usecase.NatsPublisher, logger *logger.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:       outbox,
+		publisher:    publisher,
+		logger:       logger,
+		pollInterval: relayPollInterval,
+		batchSize:    relayBatchSize,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.relayPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayPending delivers due events batch by batch until the outbox has none left.
+func (r *OutboxRelay) relayPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		records, err := r.outbox.FetchPending(ctx, r.batchSize)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to fetch pending outbox events", "error", err)
+			return
+		}
+
+		for _, record := range records {
+			if err := r.deliver(ctx, record); err != nil {
+				r.logger.ErrorContext(ctx, "Failed to update outbox event", "event_id", record.Event.EventID, "error", err)
+				return
+			}
+		}
+
+		if len(records) < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) deliver(ctx context.Context, record *repositories.OutboxRecord) error {
+	ctx, span := startDeliverySpan(ctx, record.Event)
+	defer span.End()
+
+	pubCtx, cancel := context.WithTimeout(ctx, relayPublishTimeout)
+	err := r.publish(pubCtx, record.Event)
+	cancel()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		attempts := record.Attempts + 1
+		nextAttemptAt := time.Now().Add(backoff(attempts))
+		r.logger.WarnContext(ctx, "Failed to publish outbox event, will retry",
+			"event_id", record.Event.EventID,
+			"type", record.Event.Type,
+			"attempts", attempts,
+			"next_attempt_at", nextAttemptAt,
+			"error", err)
+		return r.outbox.MarkFailed(ctx, record.Event.EventID, nextAttemptAt, err)
+	}
+
+	return r.outbox.MarkDelivered(ctx, record.Event.EventID)
+}
+
+// startDeliverySpan starts the trace of one delivery attempt. Delivery runs
+// outside the request that produced the event, so it gets a trace of its own,
+// linked to the originating span when the event carries its trace context.
+func startDeliverySpan(ctx context.Context, event *entities.OrderEvent) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.String("order.event_id", event.EventID),
+			attribute.String("order.event_type", string(event.Type)),
+			attribute.String("order.id", event.Order.OrderID),
+		),
+	}
+
+	origin := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(event.TraceContext))
+	if sc := trace.SpanContextFromContext(origin); sc.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+	}
+
+	return tracer.Start(ctx, "outbox deliver "+string(event.Type), opts...)
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *entities.OrderEvent) error {
+	switch event.Type {
+	case entities.OrderEventCreated:
+		return r.publisher.PublishOrderCreated(ctx, event.Order)
+	case entities.OrderEventStatusChanged:
+		return r.publisher.PublishOrderStatusChanged(ctx, event.Order, event.PreviousStatus, event.OccurredAt)
+	case entities.OrderEventCancelled:
+		if err := r.publisher.PublishOrderCancelled(ctx, event.Order, event.PreviousStatus); err != nil {
+			return err
+		}
+		// Consumers of every status change see cancellations too.
+		return r.publisher.PublishOrderStatusChanged(ctx, event.Order, event.PreviousStatus, event.OccurredAt)
+	case entities.OrderEventShippingUpdated:
+		return r.publisher.PublishShippingUpdated(ctx, event.Order)
+	case entities.OrderEventUpdated:
+		return r.publisher.PublishOrderUpdated(ctx, event.Order)
+	default:
+		return fmt.Errorf("unknown event type %q", event.Type)
+	}
+}
+
+// backoff returns the delay before the given delivery attempt, doubling from
+// relayMinBackoff up to relayMaxBackoff.
+func backoff(attempts int) time.Duration {
+	delay := relayMinBackoff
+	for i := 1; i < attempts && delay < relayMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > relayMaxBackoff {
+		delay = relayMaxBackoff
+	}
+	return delay
+}
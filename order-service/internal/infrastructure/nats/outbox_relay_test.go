@@ -0,0 +1,260 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) FetchPending(ctx context.Context, limit int) ([]*repositories.OutboxRecord, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repositories.OutboxRecord), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, eventID string) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) error {
+	args := m.Called(ctx, eventID, nextAttemptAt, cause)
+	return args.Error(0)
+}
+
+type MockNatsPublisher struct {
+	mock.Mock
+}
+
+func (m *MockNatsPublisher) PublishOrderCreated(ctx context.Context, order *entities.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockNatsPublisher) PublishOrderStatusChanged(ctx context.Context, order *entities.Order, previousStatus string, changedAt time.Time) error {
+	args := m.Called(ctx, order, previousStatus, changedAt)
+	return args.Error(0)
+}
+
+func (m *MockNatsPublisher) PublishOrderCancelled(ctx context.Context, order *entities.Order, previousStatus string) error {
+	args := m.Called(ctx, order, previousStatus)
+	return args.Error(0)
+}
+
+func (m *MockNatsPublisher) PublishShippingUpdated(ctx context.Context, order *entities.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockNatsPublisher) PublishOrderUpdated(ctx context.Context, order *entities.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockNatsPublisher) Close() {
+	m.Called()
+}
+
+func newCreatedRecord(eventID string, attempts int) *repositories.OutboxRecord {
+	return &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID:    eventID,
+			Type:       entities.OrderEventCreated,
+			Order:      &entities.Order{OrderID: "order-" + eventID, UserID: "user123"},
+			OccurredAt: time.Now(),
+		},
+		Attempts: attempts,
+	}
+}
+
+func TestOutboxRelay_DeliversPendingEvents(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	ctx := context.Background()
+
+	records := []*repositories.OutboxRecord{newCreatedRecord("e1", 0), newCreatedRecord("e2", 0)}
+
+	mockOutbox.On("FetchPending", mock.Anything, relayBatchSize).Return(records, nil).Once()
+	mockPublisher.On("PublishOrderCreated", mock.Anything, records[0].Event.Order).Return(nil)
+	mockPublisher.On("PublishOrderCreated", mock.Anything, records[1].Event.Order).Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e1").Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e2").Return(nil)
+
+	relay.relayPending(ctx)
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkFailed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOutboxRelay_DeliversStatusChangedEvent(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	ctx := context.Background()
+
+	changedAt := time.Now()
+	record := &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID:        "e1",
+			Type:           entities.OrderEventStatusChanged,
+			Order:          &entities.Order{OrderID: "order-1", Status: "PAID"},
+			PreviousStatus: "PENDING",
+			OccurredAt:     changedAt,
+		},
+	}
+
+	mockOutbox.On("FetchPending", mock.Anything, relayBatchSize).Return([]*repositories.OutboxRecord{record}, nil).Once()
+	mockPublisher.On("PublishOrderStatusChanged", mock.Anything, record.Event.Order, "PENDING", changedAt).Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e1").Return(nil)
+
+	relay.relayPending(ctx)
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestOutboxRelay_DeliversCancelledEvent(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	ctx := context.Background()
+
+	record := &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID: "e1",
+			Type:    entities.OrderEventCancelled,
+			Order: &entities.Order{
+				OrderID: "order-1",
+				Status:  "CANCELLED",
+				Cancellation: &entities.Cancellation{
+					CancelledAt: time.Now(),
+					Reason:      entities.CancelReasonOutOfStock,
+					CancelledBy: "warehouse",
+				},
+			},
+			PreviousStatus: "PAID",
+			OccurredAt:     time.Now(),
+		},
+	}
+
+	mockOutbox.On("FetchPending", mock.Anything, relayBatchSize).Return([]*repositories.OutboxRecord{record}, nil).Once()
+	// Published to order.cancelled and to order.status.cancelled.
+	mockPublisher.On("PublishOrderCancelled", mock.Anything, record.Event.Order, "PAID").Return(nil)
+	mockPublisher.On("PublishOrderStatusChanged", mock.Anything, record.Event.Order, "PAID", record.Event.OccurredAt).Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e1").Return(nil)
+
+	relay.relayPending(ctx)
+
+	assert.Equal(t, "order.status.cancelled", statusChangedSubject(record.Event.Order.Status))
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestOutboxRelay_DeliversUpdatedEvent(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	ctx := context.Background()
+
+	record := &repositories.OutboxRecord{
+		Event: &entities.OrderEvent{
+			EventID:    "e1",
+			Type:       entities.OrderEventUpdated,
+			Order:      &entities.Order{OrderID: "order-1", Status: "PENDING", Version: 2},
+			OccurredAt: time.Now(),
+		},
+	}
+
+	mockOutbox.On("FetchPending", mock.Anything, relayBatchSize).Return([]*repositories.OutboxRecord{record}, nil).Once()
+	mockPublisher.On("PublishOrderUpdated", mock.Anything, record.Event.Order).Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e1").Return(nil)
+
+	relay.relayPending(ctx)
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestOutboxRelay_PublishFailureSchedulesRetry(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	ctx := context.Background()
+
+	record := newCreatedRecord("e1", 2)
+	publishErr := errors.New("nats connection failed")
+
+	mockOutbox.On("FetchPending", mock.Anything, relayBatchSize).Return([]*repositories.OutboxRecord{record}, nil).Once()
+	mockPublisher.On("PublishOrderCreated", mock.Anything, record.Event.Order).Return(publishErr)
+
+	before := time.Now()
+	mockOutbox.On("MarkFailed", mock.Anything, "e1", mock.MatchedBy(func(next time.Time) bool {
+		// Third attempt: 1s doubled twice.
+		return !next.Before(before.Add(4 * time.Second))
+	}), publishErr).Return(nil)
+
+	relay.relayPending(ctx)
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockOutbox.AssertNotCalled(t, "MarkDelivered", mock.Anything, mock.Anything)
+}
+
+func TestOutboxRelay_DrainsFullBatches(t *testing.T) {
+	mockOutbox := new(MockOutboxRepository)
+	mockPublisher := new(MockNatsPublisher)
+
+	relay := NewOutboxRelay(mockOutbox, mockPublisher, logger.NewLogger())
+	relay.batchSize = 1
+	ctx := context.Background()
+
+	first := newCreatedRecord("e1", 0)
+
+	mockOutbox.On("FetchPending", mock.Anything, 1).Return([]*repositories.OutboxRecord{first}, nil).Once()
+	mockOutbox.On("FetchPending", mock.Anything, 1).Return([]*repositories.OutboxRecord{}, nil).Once()
+	mockPublisher.On("PublishOrderCreated", mock.Anything, first.Event.Order).Return(nil)
+	mockOutbox.On("MarkDelivered", mock.Anything, "e1").Return(nil)
+
+	relay.relayPending(ctx)
+
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 30, want: relayMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.attempts), "attempts=%d", tt.attempts)
+	}
+}
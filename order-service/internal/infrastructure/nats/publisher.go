@@ -4,27 +4,110 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"order-service/internal/domain/entities"
 	"order-service/internal/infrastructure/logger"
+	"order-service/internal/infrastructure/metrics"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("order-service/internal/infrastructure/nats")
+
+const (
+	// OrdersStream is the JetStream stream that persists all order events.
+	OrdersStream = "ORDERS"
+	// ordersDuplicateWindow is how long JetStream remembers message IDs for dedup.
+	ordersDuplicateWindow = 2 * time.Minute
+	// publishRetryDelay is the pause between attempts to publish an event.
+	publishRetryDelay = 1 * time.Second
 )
 
 type NatsPublisher struct {
-	nc     *nats.Conn
+	nc *nats.Conn
+	// js is nil when publishing over core NATS.
+	js     jetstream.JetStream
 	logger *logger.Logger
 }
 
+// OrderCreatedEvent carries the total as exact minor units in Total. TotalAmount
+// is the same value in major units, kept for consumers that predate Total.
+// Subtotal is the total before the Discounts given by PromoCode; Tax is all tax
+// levied in Region.
 type OrderCreatedEvent struct {
-	OrderID     string  `json:"order_id"`
-	UserID      string  `json:"user_id"`
-	TotalAmount float64 `json:"total_amount"`
-	CreatedAt   string  `json:"created_at"`
+	OrderID     string              `json:"order_id"`
+	UserID      string              `json:"user_id"`
+	Currency    string              `json:"currency"`
+	Subtotal    entities.Money      `json:"subtotal"`
+	PromoCode   string              `json:"promo_code,omitempty"`
+	Discounts   []entities.Discount `json:"discounts,omitempty"`
+	Region      string              `json:"region,omitempty"`
+	Tax         entities.Money      `json:"tax"`
+	Total       entities.Money      `json:"total"`
+	TotalAmount float64             `json:"total_amount"`
+	CreatedAt   string              `json:"created_at"`
+
+	ShippingAddress *entities.ShippingAddress `json:"shipping_address,omitempty"`
+	DeliveryMethod  entities.DeliveryMethod   `json:"delivery_method,omitempty"`
+}
+
+// OrderShippingUpdatedEvent carries the shipping details of an order after a
+// change; Version orders the changes of one order.
+type OrderShippingUpdatedEvent struct {
+	OrderID         string                    `json:"order_id"`
+	UserID          string                    `json:"user_id"`
+	ShippingAddress *entities.ShippingAddress `json:"shipping_address,omitempty"`
+	DeliveryMethod  entities.DeliveryMethod   `json:"delivery_method"`
+	Version         int64                     `json:"version"`
+	UpdatedAt       string                    `json:"updated_at"`
 }
 
-func NewNatsPublisher(url string, logger *logger.Logger) (*NatsPublisher, error) {
+// OrderUpdatedEvent carries the items and amounts of an order after its items
+// were edited; the amounts are as in OrderCreatedEvent. Version orders the
+// changes of one order.
+type OrderUpdatedEvent struct {
+	OrderID   string              `json:"order_id"`
+	UserID    string              `json:"user_id"`
+	Items     []entities.Item     `json:"items"`
+	Currency  string              `json:"currency"`
+	Subtotal  entities.Money      `json:"subtotal"`
+	PromoCode string              `json:"promo_code,omitempty"`
+	Discounts []entities.Discount `json:"discounts,omitempty"`
+	Tax       entities.Money      `json:"tax"`
+	Total     entities.Money      `json:"total"`
+	Version   int64               `json:"version"`
+	UpdatedAt string              `json:"updated_at"`
+}
+
+type OrderStatusChangedEvent struct {
+	OrderID   string `json:"order_id"`
+	UserID    string `json:"user_id"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+	ChangedAt string `json:"changed_at"`
+}
+
+type OrderCancelledEvent struct {
+	OrderID        string `json:"order_id"`
+	UserID         string `json:"user_id"`
+	PreviousStatus string `json:"previous_status"`
+	Reason         string `json:"reason"`
+	Comment        string `json:"comment,omitempty"`
+	CancelledBy    string `json:"cancelled_by"`
+	CancelledAt    string `json:"cancelled_at"`
+}
+
+// NewNatsPublisher connects to NATS. With useJetStream set, it also ensures the
+// ORDERS stream exists and publishes through JetStream, waiting for the server
+// to acknowledge every event.
+func NewNatsPublisher(url string, useJetStream bool, logger *logger.Logger) (*NatsPublisher, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -46,7 +129,14 @@ func NewNatsPublisher(url string, logger *logger.Logger) (*NatsPublisher, error)
 
 		if err == nil {
 			logger.Info("Connected to NATS", "url", url)
-			return &NatsPublisher{nc: nc, logger: logger}, nil
+			publisher := &NatsPublisher{nc: nc, logger: logger}
+			if useJetStream {
+				if err := publisher.initJetStream(ctx); err != nil {
+					nc.Close()
+					return nil, err
+				}
+			}
+			return publisher, nil
 		}
 
 		logger.Warn("Failed to connect to NATS", "attempt", i+1, "error", err)
@@ -62,48 +152,283 @@ func NewNatsPublisher(url string, logger *logger.Logger) (*NatsPublisher, error)
 	return nil, fmt.Errorf("failed to connect to NATS after retries: %w", err)
 }
 
+func (p *NatsPublisher) initJetStream(ctx context.Context) error {
+	js, err := jetstream.New(p.nc)
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       OrdersStream,
+		Subjects:   []string{"order.>"},
+		Storage:    jetstream.FileStorage,
+		Duplicates: ordersDuplicateWindow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure %s stream: %w", OrdersStream, err)
+	}
+
+	p.js = js
+	p.logger.Info("JetStream stream ready", "stream", OrdersStream)
+	return nil
+}
+
 func (p *NatsPublisher) PublishOrderCreated(ctx context.Context, order *entities.Order) error {
 	event := OrderCreatedEvent{
 		OrderID:     order.OrderID,
 		UserID:      order.UserID,
-		TotalAmount: order.TotalAmount,
+		Currency:    order.Currency,
+		Subtotal:    order.Subtotal,
+		PromoCode:   order.PromoCode,
+		Discounts:   order.Discounts,
+		Region:      order.Region,
+		Tax:         order.TaxAmount,
+		Total:       order.TotalAmount,
+		TotalAmount: order.TotalAmount.Float(),
 		CreatedAt:   order.CreatedAt.Format(time.RFC3339),
+
+		ShippingAddress: order.ShippingAddress,
+		DeliveryMethod:  order.DeliveryMethod,
+	}
+
+	return p.publish(ctx, "order.created", event, order.OrderID, order.OrderID)
+}
+
+// PublishShippingUpdated publishes to order.shipping_updated.
+func (p *NatsPublisher) PublishShippingUpdated(ctx context.Context, order *entities.Order) error {
+	event := OrderShippingUpdatedEvent{
+		OrderID:         order.OrderID,
+		UserID:          order.UserID,
+		ShippingAddress: order.ShippingAddress,
+		DeliveryMethod:  order.DeliveryMethod,
+		Version:         order.Version,
+		UpdatedAt:       order.UpdatedAt.Format(time.RFC3339),
+	}
+
+	// Each update produces a new version of the order.
+	msgID := fmt.Sprintf("%s:shipping:%d", order.OrderID, order.Version)
+	return p.publish(ctx, "order.shipping_updated", event, order.OrderID, msgID)
+}
+
+// PublishOrderUpdated publishes to order.updated.
+func (p *NatsPublisher) PublishOrderUpdated(ctx context.Context, order *entities.Order) error {
+	event := OrderUpdatedEvent{
+		OrderID:   order.OrderID,
+		UserID:    order.UserID,
+		Items:     order.Items,
+		Currency:  order.Currency,
+		Subtotal:  order.Subtotal,
+		PromoCode: order.PromoCode,
+		Discounts: order.Discounts,
+		Tax:       order.TaxAmount,
+		Total:     order.TotalAmount,
+		Version:   order.Version,
+		UpdatedAt: order.UpdatedAt.Format(time.RFC3339),
+	}
+
+	// Each edit produces a new version of the order.
+	msgID := fmt.Sprintf("%s:updated:%d", order.OrderID, order.Version)
+	return p.publish(ctx, "order.updated", event, order.OrderID, msgID)
+}
+
+// PublishOrderStatusChanged publishes to order.status.<status>, e.g. order.status.paid,
+// so consumers can subscribe to the transitions they care about or to order.status.>.
+func (p *NatsPublisher) PublishOrderStatusChanged(ctx context.Context, order *entities.Order, previousStatus string, changedAt time.Time) error {
+	event := OrderStatusChangedEvent{
+		OrderID:   order.OrderID,
+		UserID:    order.UserID,
+		OldStatus: previousStatus,
+		NewStatus: order.Status,
+		ChangedAt: changedAt.Format(time.RFC3339),
+	}
+
+	// An order reaches each status at most once, so the pair identifies the event.
+	msgID := order.OrderID + ":" + order.Status
+	return p.publish(ctx, statusChangedSubject(order.Status), event, order.OrderID, msgID)
+}
+
+// PublishOrderCancelled publishes to order.cancelled. Orders cancelled through
+// UpdateOrderStatus carry no reason and are announced as status changes only.
+func (p *NatsPublisher) PublishOrderCancelled(ctx context.Context, order *entities.Order, previousStatus string) error {
+	if order.Cancellation == nil {
+		return fmt.Errorf("order %s has no cancellation details", order.OrderID)
 	}
 
+	event := OrderCancelledEvent{
+		OrderID:        order.OrderID,
+		UserID:         order.UserID,
+		PreviousStatus: previousStatus,
+		Reason:         string(order.Cancellation.Reason),
+		Comment:        order.Cancellation.Comment,
+		CancelledBy:    order.Cancellation.CancelledBy,
+		CancelledAt:    order.Cancellation.CancelledAt.Format(time.RFC3339),
+	}
+
+	// An order is cancelled at most once.
+	return p.publish(ctx, "order.cancelled", event, order.OrderID, order.OrderID+":cancelled")
+}
+
+func statusChangedSubject(status string) string {
+	return "order.status." + strings.ToLower(status)
+}
+
+// publish sends event to subject. msgID is used by JetStream to drop duplicates
+// of the same event, e.g. when the outbox relay redelivers it. The trace
+// context of ctx is propagated to consumers in the message headers.
+func (p *NatsPublisher) publish(ctx context.Context, subject string, event interface{}, orderID, msgID string) (err error) {
+	ctx, span := tracer.Start(ctx, "send "+subject,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(subject),
+			semconv.MessagingMessageID(msgID),
+		))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	data, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	subject := "order.created"
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Header))
+
+	if p.js != nil {
+		return p.publishJetStream(ctx, msg, orderID, msgID)
+	}
 
 	for i := 0; i < 3; i++ {
 		select {
 		case <-ctx.Done():
-			p.logger.Warn("Context cancelled while publishing to NATS")
+			metrics.EventPublishFailed(subject)
+			p.logger.WarnContext(ctx, "Context cancelled while publishing to NATS")
 			return ctx.Err()
 		default:
-			err := p.nc.Publish(subject, data)
+			if i > 0 {
+				metrics.EventPublishRetried(subject)
+			}
+
+			err := p.nc.PublishMsg(msg)
 			if err != nil {
-				p.logger.Warn("Failed to publish to NATS", "attempt", i+1, "error", err)
-				time.Sleep(1 * time.Second)
+				p.logger.WarnContext(ctx, "Failed to publish to NATS", "attempt", i+1, "error", err)
+				if err := waitRetry(ctx); err != nil {
+					metrics.EventPublishFailed(subject)
+					p.logger.WarnContext(ctx, "Context cancelled while publishing to NATS")
+					return err
+				}
 				continue
 			}
 
 			if err := p.nc.FlushTimeout(2 * time.Second); err != nil {
-				p.logger.Warn("Failed to flush NATS connection", "error", err)
+				p.logger.WarnContext(ctx, "Failed to flush NATS connection", "error", err)
 				continue
 			}
 
-			p.logger.Info("Successfully published order.created event", "order_id", order.OrderID)
+			metrics.EventPublished(subject)
+			p.logger.InfoContext(ctx, "Successfully published event", "subject", subject, "order_id", orderID)
 			return nil
 		}
 	}
 
-	p.logger.Error("Failed to publish event to NATS after retries", "order_id", order.OrderID)
+	metrics.EventPublishFailed(subject)
+	p.logger.ErrorContext(ctx, "Failed to publish event to NATS after retries", "subject", subject, "order_id", orderID)
 	return fmt.Errorf("failed to publish event after retries")
 }
 
+func (p *NatsPublisher) publishJetStream(ctx context.Context, msg *nats.Msg, orderID, msgID string) error {
+	subject := msg.Subject
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			metrics.EventPublishRetried(subject)
+		}
+
+		ack, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(msgID))
+		if err != nil {
+			if ctx.Err() != nil {
+				metrics.EventPublishFailed(subject)
+				p.logger.WarnContext(ctx, "Context cancelled while publishing to JetStream")
+				return ctx.Err()
+			}
+			p.logger.WarnContext(ctx, "Failed to publish to JetStream", "attempt", i+1, "error", err)
+			if err := waitRetry(ctx); err != nil {
+				metrics.EventPublishFailed(subject)
+				p.logger.WarnContext(ctx, "Context cancelled while publishing to JetStream")
+				return err
+			}
+			continue
+		}
+
+		metrics.EventPublished(subject)
+
+		if ack.Duplicate {
+			p.logger.InfoContext(ctx, "JetStream dropped duplicate event", "subject", subject, "order_id", orderID, "msg_id", msgID)
+			return nil
+		}
+
+		p.logger.InfoContext(ctx, "Successfully published event",
+			"subject", subject,
+			"order_id", orderID,
+			"stream", ack.Stream,
+			"sequence", ack.Sequence)
+		return nil
+	}
+
+	metrics.EventPublishFailed(subject)
+	p.logger.ErrorContext(ctx, "Failed to publish event to JetStream after retries", "subject", subject, "order_id", orderID)
+	return fmt.Errorf("failed to publish event after retries")
+}
+
+// waitRetry pauses for publishRetryDelay before the next publish attempt. It
+// returns early with the error of ctx once ctx is done.
+func waitRetry(ctx context.Context) error {
+	timer := time.NewTimer(publishRetryDelay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// headerCarrier adapts NATS message headers for OpenTelemetry propagators.
+// NATS headers are case-sensitive, so keys are kept exactly as the propagator
+// writes them, e.g. "traceparent".
+type headerCarrier nats.Header
+
+func (c headerCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Ping reports whether the connection to the NATS server is currently up.
+func (p *NatsPublisher) Ping(ctx context.Context) error {
+	if status := p.nc.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("NATS connection is %s", status)
+	}
+	return nil
+}
+
 func (p *NatsPublisher) Close() {
 	if p.nc != nil && p.nc.IsConnected() {
 		p.nc.Close()
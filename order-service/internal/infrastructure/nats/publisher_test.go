@@ -0,0 +1,25 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusChangedSubject(t *testing.T) {
+	assert.Equal(t, "order.status.paid", statusChangedSubject("PAID"))
+	assert.Equal(t, "order.status.cancelled", statusChangedSubject("CANCELLED"))
+}
+
+func TestWaitRetry_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := waitRetry(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), publishRetryDelay)
+}
@@ -0,0 +1,90 @@
+package nats
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	spanExporter    = tracetest.NewInMemoryExporter()
+	installProvider sync.Once
+)
+
+// setupTracing installs a recording tracer provider. The package tracer binds
+// to the first global provider, so it is installed once and reset per test.
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	installProvider.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+	spanExporter.Reset()
+	return spanExporter
+}
+
+func TestStartDeliverySpan_LinksOriginatingSpan(t *testing.T) {
+	exporter := setupTracing(t)
+
+	requestCtx, requestSpan := otel.Tracer("test").Start(context.Background(), "CreateOrder")
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(requestCtx, carrier)
+	requestSpan.End()
+
+	event := &entities.OrderEvent{
+		EventID:      "e1",
+		Type:         entities.OrderEventCreated,
+		Order:        &entities.Order{OrderID: "order-1"},
+		TraceContext: carrier,
+	}
+
+	_, span := startDeliverySpan(context.Background(), event)
+	span.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	delivery := spans[1]
+	assert.Equal(t, "outbox deliver order.created", delivery.Name)
+	assert.NotEqual(t, requestSpan.SpanContext().TraceID(), delivery.SpanContext.TraceID())
+	if assert.Len(t, delivery.Links, 1) {
+		assert.Equal(t, requestSpan.SpanContext().SpanID(), delivery.Links[0].SpanContext.SpanID())
+	}
+}
+
+func TestStartDeliverySpan_WithoutTraceContext(t *testing.T) {
+	exporter := setupTracing(t)
+
+	event := &entities.OrderEvent{EventID: "e1", Type: entities.OrderEventCreated, Order: &entities.Order{OrderID: "order-1"}}
+
+	_, span := startDeliverySpan(context.Background(), event)
+	span.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Links)
+}
+
+func TestHeaderCarrier_PropagatesTraceContext(t *testing.T) {
+	setupTracing(t)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "send")
+	defer span.End()
+
+	msg := nats.NewMsg("order.created")
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Header))
+
+	assert.NotEmpty(t, msg.Header.Get("traceparent"))
+
+	extracted := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(msg.Header))
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+}
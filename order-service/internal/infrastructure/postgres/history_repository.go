@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+func (r *OrderRepositoryPostgres) GetHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error) {
+	var exists bool
+	if err := r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM orders WHERE order_id = $1)", orderID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+	if !exists {
+		return nil, repositories.ErrOrderNotFound
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT from_status, to_status, changed_at, actor, reason, version
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY version`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status history: %w", err)
+	}
+
+	history, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.StatusChange, error) {
+		var change entities.StatusChange
+		err := row.Scan(&change.From, &change.To, &change.ChangedAt, &change.Actor, &change.Reason, &change.Version)
+		change.ChangedAt = change.ChangedAt.UTC()
+		return change, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode status history: %w", err)
+	}
+
+	return history, nil
+}
+
+func insertStatusChange(ctx context.Context, tx pgx.Tx, orderID string, change entities.StatusChange) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_status_history (order_id, version, from_status, to_status, changed_at, actor, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		orderID, change.Version, change.From, change.To, change.ChangedAt, change.Actor, change.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert status change: %w", err)
+	}
+	return nil
+}
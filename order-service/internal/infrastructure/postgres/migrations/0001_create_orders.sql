@@ -0,0 +1,46 @@
+CREATE TABLE orders (
+    order_id        TEXT PRIMARY KEY,
+    user_id         TEXT        NOT NULL,
+    currency        TEXT        NOT NULL,
+    total_minor     BIGINT      NOT NULL,
+    status          TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    version         BIGINT      NOT NULL,
+    idempotency_key TEXT,
+    request_hash    TEXT
+);
+
+CREATE UNIQUE INDEX orders_user_idempotency_key_idx
+    ON orders (user_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- List orders by created_at DESC, order_id DESC, optionally by user or status.
+CREATE INDEX orders_created_idx ON orders (created_at DESC, order_id DESC);
+CREATE INDEX orders_user_created_idx ON orders (user_id, created_at DESC, order_id DESC);
+CREATE INDEX orders_status_created_idx ON orders (status, created_at DESC, order_id DESC);
+
+CREATE TABLE order_items (
+    order_id    TEXT   NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    position    INT    NOT NULL,
+    product_id  TEXT   NOT NULL,
+    quantity    INT    NOT NULL,
+    price_minor BIGINT NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
+
+-- Undelivered events; delivered ones are deleted.
+CREATE TABLE outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    event_id        TEXT        NOT NULL UNIQUE,
+    event_type      TEXT        NOT NULL,
+    order_id        TEXT        NOT NULL,
+    order_snapshot  JSONB       NOT NULL,
+    previous_status TEXT        NOT NULL DEFAULT '',
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    trace_context   JSONB,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX outbox_next_attempt_idx ON outbox (next_attempt_at, id);
@@ -0,0 +1,6 @@
+-- Set together when an order is cancelled through CancelOrder.
+ALTER TABLE orders
+    ADD COLUMN cancelled_at   TIMESTAMPTZ,
+    ADD COLUMN cancel_reason  TEXT,
+    ADD COLUMN cancel_comment TEXT,
+    ADD COLUMN cancelled_by   TEXT;
@@ -0,0 +1,18 @@
+-- Orders written before this migration were last updated at creation as far
+-- as we know.
+ALTER TABLE orders ADD COLUMN updated_at TIMESTAMPTZ;
+UPDATE orders SET updated_at = created_at;
+ALTER TABLE orders ALTER COLUMN updated_at SET NOT NULL;
+
+-- Append-only: rows are inserted with the order change that produced version
+-- and never updated.
+CREATE TABLE order_status_history (
+    order_id    TEXT        NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    version     BIGINT      NOT NULL,
+    from_status TEXT        NOT NULL DEFAULT '',
+    to_status   TEXT        NOT NULL,
+    changed_at  TIMESTAMPTZ NOT NULL,
+    actor       TEXT        NOT NULL DEFAULT '',
+    reason      TEXT        NOT NULL DEFAULT '',
+    PRIMARY KEY (order_id, version)
+);
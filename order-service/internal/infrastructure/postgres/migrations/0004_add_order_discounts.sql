@@ -0,0 +1,15 @@
+-- Orders written before this migration were never discounted.
+ALTER TABLE orders ADD COLUMN subtotal_minor BIGINT;
+UPDATE orders SET subtotal_minor = total_minor;
+ALTER TABLE orders ALTER COLUMN subtotal_minor SET NOT NULL;
+
+ALTER TABLE orders ADD COLUMN promo_code TEXT;
+
+CREATE TABLE order_discounts (
+    order_id     TEXT   NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    position     INT    NOT NULL,
+    promo_code   TEXT   NOT NULL,
+    description  TEXT   NOT NULL,
+    amount_minor BIGINT NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
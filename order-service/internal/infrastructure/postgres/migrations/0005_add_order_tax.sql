@@ -0,0 +1,9 @@
+-- Orders written before this migration carry no tax.
+ALTER TABLE orders ADD COLUMN region TEXT;
+ALTER TABLE orders ADD COLUMN tax_minor BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_items ADD COLUMN category TEXT NOT NULL DEFAULT '';
+-- The tax columns are either all NULL, for items of untaxed orders, or all set.
+ALTER TABLE order_items ADD COLUMN tax_rate_bps BIGINT;
+ALTER TABLE order_items ADD COLUMN tax_inclusive BOOLEAN;
+ALTER TABLE order_items ADD COLUMN tax_minor BIGINT;
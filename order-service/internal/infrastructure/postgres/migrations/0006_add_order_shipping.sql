@@ -0,0 +1,11 @@
+-- The shipping columns are either all NULL, for orders without an address, or
+-- set with at least the recipient, line1, city, postal code and country.
+ALTER TABLE orders ADD COLUMN delivery_method TEXT;
+ALTER TABLE orders ADD COLUMN shipping_recipient_name TEXT;
+ALTER TABLE orders ADD COLUMN shipping_phone TEXT;
+ALTER TABLE orders ADD COLUMN shipping_line1 TEXT;
+ALTER TABLE orders ADD COLUMN shipping_line2 TEXT;
+ALTER TABLE orders ADD COLUMN shipping_city TEXT;
+ALTER TABLE orders ADD COLUMN shipping_state TEXT;
+ALTER TABLE orders ADD COLUMN shipping_postal_code TEXT;
+ALTER TABLE orders ADD COLUMN shipping_country_code TEXT;
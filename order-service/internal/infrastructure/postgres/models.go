@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"time"
+
+	"order-service/internal/domain/entities"
+)
+
+// orderSnapshot is the form of an order kept in outbox.order_snapshot, so that
+// an event carries the order as it was when the event was recorded.
+type orderSnapshot struct {
+	OrderID        string             `json:"order_id"`
+	UserID         string             `json:"user_id"`
+	Items          []itemSnapshot     `json:"items"`
+	Currency       string             `json:"currency"`
+	SubtotalMinor  int64              `json:"subtotal_minor,omitempty"`
+	PromoCode      string             `json:"promo_code,omitempty"`
+	Discounts      []discountSnapshot `json:"discounts,omitempty"`
+	Region         string             `json:"region,omitempty"`
+	TaxMinor       int64              `json:"tax_minor,omitempty"`
+	TotalMinor     int64              `json:"total_minor"`
+	DeliveryMethod string             `json:"delivery_method,omitempty"`
+	Status         string             `json:"status"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	Version        int64              `json:"version"`
+	IdempotencyKey string             `json:"idempotency_key,omitempty"`
+
+	ShippingAddress *entities.ShippingAddress `json:"shipping_address,omitempty"`
+	Cancellation    *entities.Cancellation    `json:"cancellation,omitempty"`
+}
+
+type itemSnapshot struct {
+	ProductID  string       `json:"product_id"`
+	Quantity   int          `json:"quantity"`
+	PriceMinor int64        `json:"price_minor"`
+	Category   string       `json:"category,omitempty"`
+	Tax        *taxSnapshot `json:"tax,omitempty"`
+}
+
+type taxSnapshot struct {
+	RateBasisPoints int64 `json:"rate_basis_points"`
+	Inclusive       bool  `json:"inclusive"`
+	AmountMinor     int64 `json:"amount_minor"`
+}
+
+type discountSnapshot struct {
+	PromoCode   string `json:"promo_code"`
+	Description string `json:"description"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+func toOrderSnapshot(order *entities.Order) *orderSnapshot {
+	snapshot := &orderSnapshot{
+		OrderID:         order.OrderID,
+		UserID:          order.UserID,
+		Currency:        order.Currency,
+		SubtotalMinor:   order.Subtotal.Amount,
+		PromoCode:       order.PromoCode,
+		Region:          order.Region,
+		TaxMinor:        order.TaxAmount.Amount,
+		TotalMinor:      order.TotalAmount.Amount,
+		ShippingAddress: order.ShippingAddress,
+		DeliveryMethod:  string(order.DeliveryMethod),
+		Status:          order.Status,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+		Version:         order.Version,
+		Items:           make([]itemSnapshot, len(order.Items)),
+		IdempotencyKey:  order.IdempotencyKey,
+		Cancellation:    order.Cancellation,
+	}
+
+	for i, item := range order.Items {
+		snapshot.Items[i] = itemSnapshot{
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			PriceMinor: item.Price.Amount,
+			Category:   item.Category,
+		}
+		if item.Tax != nil {
+			snapshot.Items[i].Tax = &taxSnapshot{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				AmountMinor:     item.Tax.Amount.Amount,
+			}
+		}
+	}
+
+	for _, discount := range order.Discounts {
+		snapshot.Discounts = append(snapshot.Discounts, discountSnapshot{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			AmountMinor: discount.Amount.Amount,
+		})
+	}
+
+	return snapshot
+}
+
+func (s *orderSnapshot) toEntity() *entities.Order {
+	items := make([]entities.Item, len(s.Items))
+	for i, item := range s.Items {
+		items[i] = entities.Item{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     entities.NewMoney(item.PriceMinor, s.Currency),
+			Category:  item.Category,
+		}
+		if item.Tax != nil {
+			items[i].Tax = &entities.ItemTax{
+				RateBasisPoints: item.Tax.RateBasisPoints,
+				Inclusive:       item.Tax.Inclusive,
+				Amount:          entities.NewMoney(item.Tax.AmountMinor, s.Currency),
+			}
+		}
+	}
+
+	var discounts []entities.Discount
+	for _, discount := range s.Discounts {
+		discounts = append(discounts, entities.Discount{
+			PromoCode:   discount.PromoCode,
+			Description: discount.Description,
+			Amount:      entities.NewMoney(discount.AmountMinor, s.Currency),
+		})
+	}
+
+	// Snapshots recorded before updated_at and discounts were introduced lack them.
+	updatedAt := s.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = s.CreatedAt
+	}
+	subtotalMinor := s.SubtotalMinor
+	if subtotalMinor == 0 && len(s.Discounts) == 0 {
+		subtotalMinor = s.TotalMinor
+	}
+
+	return &entities.Order{
+		OrderID:         s.OrderID,
+		UserID:          s.UserID,
+		Items:           items,
+		Currency:        s.Currency,
+		Subtotal:        entities.NewMoney(subtotalMinor, s.Currency),
+		PromoCode:       s.PromoCode,
+		Discounts:       discounts,
+		Region:          s.Region,
+		TaxAmount:       entities.NewMoney(s.TaxMinor, s.Currency),
+		TotalAmount:     entities.NewMoney(s.TotalMinor, s.Currency),
+		ShippingAddress: s.ShippingAddress,
+		DeliveryMethod:  entities.DeliveryMethod(s.DeliveryMethod),
+		Status:          s.Status,
+		CreatedAt:       s.CreatedAt.UTC(),
+		UpdatedAt:       updatedAt.UTC(),
+		Version:         s.Version,
+		IdempotencyKey:  s.IdempotencyKey,
+		Cancellation:    s.Cancellation,
+	}
+}
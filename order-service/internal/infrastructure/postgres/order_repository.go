@@ -0,0 +1,547 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/infrastructure/logger"
+)
+
+var _ repositories.OrderStore = (*OrderRepositoryPostgres)(nil)
+
+// SQLSTATE codes mapped to repository errors.
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+const orderColumns = `order_id, user_id, currency, subtotal_minor, COALESCE(promo_code, ''), COALESCE(region, ''), tax_minor,
+	total_minor, status, created_at, updated_at, version,
+	COALESCE(idempotency_key, ''), COALESCE(request_hash, ''),
+	cancelled_at, COALESCE(cancel_reason, ''), COALESCE(cancel_comment, ''), COALESCE(cancelled_by, ''),
+	COALESCE(delivery_method, ''), ` + shippingColumns
+
+// shippingColumns are the shipping address columns, NULLs read as empty strings.
+const shippingColumns = `COALESCE(shipping_recipient_name, ''), COALESCE(shipping_phone, ''),
+	COALESCE(shipping_line1, ''), COALESCE(shipping_line2, ''), COALESCE(shipping_city, ''), COALESCE(shipping_state, ''),
+	COALESCE(shipping_postal_code, ''), COALESCE(shipping_country_code, '')`
+
+// OrderRepositoryPostgres stores orders in the orders, order_items and
+// order_discounts tables and their events in the outbox table. The schema is created and upgraded by
+// the embedded migrations when the repository is opened.
+type OrderRepositoryPostgres struct {
+	pool   *pgxpool.Pool
+	logger *logger.Logger
+}
+
+func NewOrderRepositoryPostgres(dsn string, logger *logger.Logger) (*OrderRepositoryPostgres, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PostgreSQL DSN: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	r := &OrderRepositoryPostgres{pool: pool, logger: logger}
+	if err := r.migrate(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to migrate PostgreSQL schema: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *OrderRepositoryPostgres) Close() error {
+	r.pool.Close()
+	return nil
+}
+
+// Ping checks that a connection can be made; used by health checks.
+func (r *OrderRepositoryPostgres) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
+
+// Create inserts the order, its items and discounts, its first status history
+// entry and its outbox event in one transaction.
+func (r *OrderRepositoryPostgres) Create(ctx context.Context, order *entities.Order, event *entities.OrderEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO orders (order_id, user_id, currency, subtotal_minor, promo_code, region, tax_minor, total_minor,
+				status, created_at, updated_at, version, idempotency_key, request_hash, delivery_method,
+				shipping_recipient_name, shipping_phone, shipping_line1, shipping_line2, shipping_city, shipping_state,
+				shipping_postal_code, shipping_country_code)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+				$16, $17, $18, $19, $20, $21, $22, $23)`,
+			append([]any{order.OrderID, order.UserID, order.Currency, order.Subtotal.Amount, nullIfEmpty(order.PromoCode),
+				nullIfEmpty(order.Region), order.TaxAmount.Amount, order.TotalAmount.Amount, order.Status,
+				order.CreatedAt, order.UpdatedAt, order.Version, nullIfEmpty(order.IdempotencyKey),
+				nullIfEmpty(order.RequestHash), nullIfEmpty(string(order.DeliveryMethod))},
+				shippingArgs(order.ShippingAddress)...)...)
+		if err != nil {
+			return err
+		}
+
+		if err := insertItems(ctx, tx, order.OrderID, order.Items); err != nil {
+			return err
+		}
+		if err := insertDiscounts(ctx, tx, order.OrderID, order.Discounts); err != nil {
+			return err
+		}
+
+		if err := insertStatusChange(ctx, tx, order.OrderID, order.CreationChange()); err != nil {
+			return err
+		}
+		return insertOutboxEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return mapError(err, "failed to insert order")
+	}
+
+	return nil
+}
+
+func insertItems(ctx context.Context, tx pgx.Tx, orderID string, items []entities.Item) error {
+	rows := make([][]any, len(items))
+	for i, item := range items {
+		var taxRateBps, taxMinor *int64
+		var taxInclusive *bool
+		if item.Tax != nil {
+			taxRateBps, taxInclusive, taxMinor = &item.Tax.RateBasisPoints, &item.Tax.Inclusive, &item.Tax.Amount.Amount
+		}
+		rows[i] = []any{orderID, i, item.ProductID, item.Quantity, item.Price.Amount, item.Category,
+			taxRateBps, taxInclusive, taxMinor}
+	}
+	_, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"order_items"},
+		[]string{"order_id", "position", "product_id", "quantity", "price_minor", "category",
+			"tax_rate_bps", "tax_inclusive", "tax_minor"},
+		pgx.CopyFromRows(rows))
+	return err
+}
+
+func insertDiscounts(ctx context.Context, tx pgx.Tx, orderID string, discounts []entities.Discount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	rows := make([][]any, len(discounts))
+	for i, discount := range discounts {
+		rows[i] = []any{orderID, i, discount.PromoCode, discount.Description, discount.Amount.Amount}
+	}
+	_, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"order_discounts"},
+		[]string{"order_id", "position", "promo_code", "description", "amount_minor"},
+		pgx.CopyFromRows(rows))
+	return err
+}
+
+func (r *OrderRepositoryPostgres) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
+	return r.getOne(ctx, "order_id = $1", orderID)
+}
+
+func (r *OrderRepositoryPostgres) GetByIdempotencyKey(ctx context.Context, userID, key string) (*entities.Order, error) {
+	return r.getOne(ctx, "user_id = $1 AND idempotency_key = $2", userID, key)
+}
+
+func (r *OrderRepositoryPostgres) getOne(ctx context.Context, where string, args ...any) (*entities.Order, error) {
+	orders, err := r.query(ctx, "SELECT "+orderColumns+" FROM orders WHERE "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+	if len(orders) == 0 {
+		return nil, repositories.ErrOrderNotFound
+	}
+	return orders[0], nil
+}
+
+// UpdateStatus locks the order row, so that concurrent updates of one order
+// are serialized and all but the first fail the version check.
+func (r *OrderRepositoryPostgres) UpdateStatus(ctx context.Context, orderID string, change entities.StatusChange, expectedVersion int64, event *entities.OrderEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var current string
+		var version int64
+		err := tx.QueryRow(ctx, "SELECT status, version FROM orders WHERE order_id = $1 FOR UPDATE", orderID).
+			Scan(&current, &version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+		if !entities.CanTransition(current, change.To) {
+			return repositories.ErrInvalidTransition
+		}
+		if current == change.To {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE orders SET status = $2, updated_at = $3, version = version + 1 WHERE order_id = $1",
+			orderID, change.To, change.ChangedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := insertStatusChange(ctx, tx, orderID, change); err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		return insertOutboxEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return mapError(err, "failed to update order")
+	}
+
+	r.logger.DebugContext(ctx, "Order status updated successfully",
+		"order_id", orderID,
+		"new_status", change.To,
+		"version", expectedVersion+1)
+
+	return nil
+}
+
+// Cancel locks the order row like UpdateStatus does.
+func (r *OrderRepositoryPostgres) Cancel(ctx context.Context, orderID string, cancellation entities.Cancellation, expectedVersion int64, event *entities.OrderEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var current string
+		var version int64
+		err := tx.QueryRow(ctx, "SELECT status, version FROM orders WHERE order_id = $1 FOR UPDATE", orderID).
+			Scan(&current, &version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+		if !entities.OrderStatus(current).Cancellable() {
+			return repositories.ErrInvalidTransition
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET status = $2, updated_at = $3, version = version + 1,
+				cancelled_at = $3, cancel_reason = $4, cancel_comment = $5, cancelled_by = $6
+			WHERE order_id = $1`,
+			orderID, string(entities.OrderStatusCancelled), cancellation.CancelledAt,
+			string(cancellation.Reason), cancellation.Comment, cancellation.CancelledBy)
+		if err != nil {
+			return err
+		}
+
+		if err := insertStatusChange(ctx, tx, orderID, cancellation.StatusChange(current, version+1)); err != nil {
+			return err
+		}
+		return insertOutboxEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return mapError(err, "failed to cancel order")
+	}
+
+	return nil
+}
+
+func (r *OrderRepositoryPostgres) UpdateShipping(ctx context.Context, orderID string, update entities.ShippingUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var version int64
+		err := tx.QueryRow(ctx, "SELECT version FROM orders WHERE order_id = $1 FOR UPDATE", orderID).Scan(&version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET updated_at = $2, version = version + 1, delivery_method = $3,
+				shipping_recipient_name = $4, shipping_phone = $5, shipping_line1 = $6, shipping_line2 = $7,
+				shipping_city = $8, shipping_state = $9, shipping_postal_code = $10, shipping_country_code = $11
+			WHERE order_id = $1`,
+			append([]any{orderID, update.UpdatedAt, nullIfEmpty(string(update.DeliveryMethod))},
+				shippingArgs(update.Address)...)...)
+		if err != nil {
+			return err
+		}
+
+		return insertOutboxEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return mapError(err, "failed to update shipping details")
+	}
+
+	return nil
+}
+
+// UpdateItems replaces the rows of the order in order_items and
+// order_discounts and its amounts in orders in one transaction.
+func (r *OrderRepositoryPostgres) UpdateItems(ctx context.Context, orderID string, update entities.ItemsUpdate, expectedVersion int64, event *entities.OrderEvent) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var version int64
+		err := tx.QueryRow(ctx, "SELECT version FROM orders WHERE order_id = $1 FOR UPDATE", orderID).Scan(&version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if version != expectedVersion {
+			return repositories.ErrVersionConflict
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET subtotal_minor = $2, tax_minor = $3, total_minor = $4, updated_at = $5, version = version + 1
+			WHERE order_id = $1`,
+			orderID, update.Subtotal.Amount, update.TaxAmount.Amount, update.TotalAmount.Amount, update.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, "DELETE FROM order_items WHERE order_id = $1", orderID); err != nil {
+			return err
+		}
+		if err := insertItems(ctx, tx, orderID, update.Items); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM order_discounts WHERE order_id = $1", orderID); err != nil {
+			return err
+		}
+		if err := insertDiscounts(ctx, tx, orderID, update.Discounts); err != nil {
+			return err
+		}
+
+		return insertOutboxEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return mapError(err, "failed to update order items")
+	}
+
+	return nil
+}
+
+// shippingArgs returns the values of the shipping address columns, all NULL
+// when there is no address.
+func shippingArgs(address *entities.ShippingAddress) []any {
+	if address == nil {
+		return make([]any, 8)
+	}
+	return []any{address.RecipientName, nullIfEmpty(address.Phone), address.Line1, nullIfEmpty(address.Line2),
+		address.City, nullIfEmpty(address.State), address.PostalCode, address.CountryCode}
+}
+
+func (r *OrderRepositoryPostgres) List(ctx context.Context, filter repositories.OrderFilter) ([]*entities.Order, error) {
+	where, args := toListQuery(filter)
+
+	sql := "SELECT " + orderColumns + " FROM orders"
+	if len(where) > 0 {
+		sql += " WHERE " + strings.Join(where, " AND ")
+	}
+	sql += " ORDER BY created_at DESC, order_id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		sql += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	orders, err := r.query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return orders, nil
+}
+
+func toListQuery(filter repositories.OrderFilter) ([]string, []any) {
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.UserID != "" {
+		where = append(where, "user_id = "+arg(filter.UserID))
+	}
+	if len(filter.Statuses) > 0 {
+		where = append(where, "status = ANY("+arg(filter.Statuses)+")")
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where = append(where, "created_at >= "+arg(filter.CreatedFrom))
+	}
+	if !filter.CreatedTo.IsZero() {
+		where = append(where, "created_at < "+arg(filter.CreatedTo))
+	}
+	if filter.After != nil {
+		where = append(where, "(created_at, order_id) < ("+arg(filter.After.CreatedAt)+", "+arg(filter.After.OrderID)+")")
+	}
+
+	return where, args
+}
+
+// query runs a select over orderColumns and loads the items and discounts of
+// the orders found.
+func (r *OrderRepositoryPostgres) query(ctx context.Context, sql string, args ...any) ([]*entities.Order, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Order, error) {
+		var order entities.Order
+		var subtotalMinor, taxMinor, totalMinor int64
+		var cancelledAt *time.Time
+		var cancellation entities.Cancellation
+		var address entities.ShippingAddress
+		err := row.Scan(&order.OrderID, &order.UserID, &order.Currency, &subtotalMinor, &order.PromoCode, &order.Region, &taxMinor,
+			&totalMinor, &order.Status, &order.CreatedAt, &order.UpdatedAt, &order.Version, &order.IdempotencyKey, &order.RequestHash,
+			&cancelledAt, &cancellation.Reason, &cancellation.Comment, &cancellation.CancelledBy,
+			&order.DeliveryMethod, &address.RecipientName, &address.Phone, &address.Line1, &address.Line2,
+			&address.City, &address.State, &address.PostalCode, &address.CountryCode)
+		order.Subtotal = entities.NewMoney(subtotalMinor, order.Currency)
+		order.TaxAmount = entities.NewMoney(taxMinor, order.Currency)
+		order.TotalAmount = entities.NewMoney(totalMinor, order.Currency)
+		order.CreatedAt = order.CreatedAt.UTC()
+		order.UpdatedAt = order.UpdatedAt.UTC()
+		order.Items = []entities.Item{}
+		if cancelledAt != nil {
+			cancellation.CancelledAt = cancelledAt.UTC()
+			order.Cancellation = &cancellation
+		}
+		// Every address has a country.
+		if address.CountryCode != "" {
+			order.ShippingAddress = &address
+		}
+		return &order, err
+	})
+	if err != nil || len(orders) == 0 {
+		return orders, err
+	}
+
+	byID := make(map[string]*entities.Order, len(orders))
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		byID[order.OrderID] = order
+		ids[i] = order.OrderID
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT order_id, product_id, quantity, price_minor, category, tax_rate_bps, tax_inclusive, tax_minor
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, position`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID string
+		var item entities.Item
+		var priceMinor int64
+		var taxRateBps, taxMinor *int64
+		var taxInclusive *bool
+		if err := rows.Scan(&orderID, &item.ProductID, &item.Quantity, &priceMinor, &item.Category,
+			&taxRateBps, &taxInclusive, &taxMinor); err != nil {
+			return nil, err
+		}
+		order := byID[orderID]
+		item.Price = entities.NewMoney(priceMinor, order.Currency)
+		if taxRateBps != nil && taxInclusive != nil && taxMinor != nil {
+			item.Tax = &entities.ItemTax{
+				RateBasisPoints: *taxRateBps,
+				Inclusive:       *taxInclusive,
+				Amount:          entities.NewMoney(*taxMinor, order.Currency),
+			}
+		}
+		order.Items = append(order.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadDiscounts(ctx, byID, ids); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *OrderRepositoryPostgres) loadDiscounts(ctx context.Context, byID map[string]*entities.Order, ids []string) error {
+	rows, err := r.pool.Query(ctx, `
+		SELECT order_id, promo_code, description, amount_minor
+		FROM order_discounts
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, position`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID string
+		var discount entities.Discount
+		var amountMinor int64
+		if err := rows.Scan(&orderID, &discount.PromoCode, &discount.Description, &amountMinor); err != nil {
+			return err
+		}
+		order := byID[orderID]
+		discount.Amount = entities.NewMoney(amountMinor, order.Currency)
+		order.Discounts = append(order.Discounts, discount)
+	}
+
+	return rows.Err()
+}
+
+// mapError translates constraint violations into repository errors and wraps
+// anything else with msg. Repository errors pass through unchanged.
+func mapError(err error, msg string) error {
+	var repoErr *repositories.RepositoryError
+	if errors.As(err, &repoErr) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return repositories.ErrOrderAlreadyExists
+		case foreignKeyViolation:
+			return repositories.ErrOrderNotFound
+		}
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
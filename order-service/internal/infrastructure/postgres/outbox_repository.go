@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+func (r *OrderRepositoryPostgres) FetchPending(ctx context.Context, limit int) ([]*repositories.OutboxRecord, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT event_id, event_type, order_snapshot, previous_status, occurred_at, trace_context, attempts
+		FROM outbox
+		WHERE next_attempt_at <= $1
+		ORDER BY id
+		LIMIT $2`, time.Now(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending events: %w", err)
+	}
+
+	records, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*repositories.OutboxRecord, error) {
+		var event entities.OrderEvent
+		var snapshot orderSnapshot
+		var attempts int
+		err := row.Scan(&event.EventID, &event.Type, &snapshot, &event.PreviousStatus,
+			&event.OccurredAt, &event.TraceContext, &attempts)
+		event.Order = snapshot.toEntity()
+		event.OccurredAt = event.OccurredAt.UTC()
+		return &repositories.OutboxRecord{Event: &event, Attempts: attempts}, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pending events: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *OrderRepositoryPostgres) MarkDelivered(ctx context.Context, eventID string) error {
+	if _, err := r.pool.Exec(ctx, "DELETE FROM outbox WHERE event_id = $1", eventID); err != nil {
+		return fmt.Errorf("failed to mark event delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *OrderRepositoryPostgres) MarkFailed(ctx context.Context, eventID string, nextAttemptAt time.Time, cause error) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE outbox
+		SET next_attempt_at = $2, last_error = $3, attempts = attempts + 1
+		WHERE event_id = $1`, eventID, nextAttemptAt, cause.Error())
+	if err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+	return nil
+}
+
+func insertOutboxEvent(ctx context.Context, tx pgx.Tx, event *entities.OrderEvent) error {
+	snapshot, err := json.Marshal(toOrderSnapshot(event.Order))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO outbox (event_id, event_type, order_id, order_snapshot, previous_status, occurred_at, trace_context, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $6)`,
+		event.EventID, string(event.Type), event.Order.OrderID, snapshot, event.PreviousStatus,
+		event.OccurredAt, event.TraceContext)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
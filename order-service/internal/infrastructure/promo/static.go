@@ -0,0 +1,145 @@
+package promo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"order-service/internal/domain/entities"
+)
+
+// Rule types of the promotions file.
+const (
+	RuleTypePercentage  = "percentage"
+	RuleTypeFixedAmount = "fixed_amount"
+	RuleTypeBuyXGetY    = "buy_x_get_y"
+	RuleTypeMinBasket   = "min_basket"
+)
+
+// StaticPromotions serves promotions from a JSON file read once at startup.
+type StaticPromotions struct {
+	promotions map[string]*entities.Promotion
+}
+
+type promotionEntry struct {
+	Code  string      `json:"code"`
+	Rules []ruleEntry `json:"rules"`
+}
+
+// ruleEntry is a discount rule as written in the promotions file; which fields
+// are used depends on Type. Amounts are in minor units of Currency.
+type ruleEntry struct {
+	Type string `json:"type"`
+	// percentage
+	Percent int64 `json:"percent"`
+	// fixed_amount and min_basket
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// buy_x_get_y
+	ProductID string `json:"product_id"`
+	Buy       int    `json:"buy"`
+	Get       int    `json:"get"`
+	// min_basket: Rule applies to orders of at least Amount.
+	Rule *ruleEntry `json:"rule"`
+}
+
+// NewStaticPromotions loads the promotions listed in the JSON file at path, e.g.
+//
+//	[{"code": "WELCOME10", "rules": [{"type": "percentage", "percent": 10}]},
+//	 {"code": "3FOR2", "rules": [{"type": "buy_x_get_y", "product_id": "prod1", "buy": 2, "get": 1}]},
+//	 {"code": "BIGBASKET", "rules": [{"type": "min_basket", "amount": 500000, "currency": "RUB",
+//	   "rule": {"type": "fixed_amount", "amount": 50000, "currency": "RUB"}}]}]
+//
+// Codes are case-insensitive.
+func NewStaticPromotions(path string) (*StaticPromotions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read promotions: %w", err)
+	}
+
+	var entries []promotionEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse promotions %s: %w", path, err)
+	}
+
+	promotions := make(map[string]*entities.Promotion, len(entries))
+	for i, entry := range entries {
+		code := strings.ToUpper(strings.TrimSpace(entry.Code))
+		if code == "" {
+			return nil, fmt.Errorf("promotions %s: entry %d has no code", path, i)
+		}
+		if _, ok := promotions[code]; ok {
+			return nil, fmt.Errorf("promotions %s: %s is listed twice", path, code)
+		}
+		if len(entry.Rules) == 0 {
+			return nil, fmt.Errorf("promotions %s: %s has no rules", path, code)
+		}
+
+		promotion := &entities.Promotion{Code: code, Rules: make([]entities.DiscountRule, len(entry.Rules))}
+		for j := range entry.Rules {
+			rule, err := toRule(&entry.Rules[j])
+			if err != nil {
+				return nil, fmt.Errorf("promotions %s: %s: rule %d: %w", path, code, j, err)
+			}
+			promotion.Rules[j] = rule
+		}
+		promotions[code] = promotion
+	}
+
+	return &StaticPromotions{promotions: promotions}, nil
+}
+
+func toRule(entry *ruleEntry) (entities.DiscountRule, error) {
+	switch entry.Type {
+	case RuleTypePercentage:
+		if entry.Percent <= 0 || entry.Percent > 100 {
+			return nil, fmt.Errorf("percent must be between 1 and 100")
+		}
+		return entities.PercentageOff{Percent: entry.Percent}, nil
+	case RuleTypeFixedAmount:
+		amount, err := toMoney(entry)
+		if err != nil {
+			return nil, err
+		}
+		return entities.FixedAmountOff{Amount: amount}, nil
+	case RuleTypeBuyXGetY:
+		if entry.ProductID == "" {
+			return nil, fmt.Errorf("product_id is required")
+		}
+		if entry.Buy <= 0 || entry.Get <= 0 {
+			return nil, fmt.Errorf("buy and get must be positive")
+		}
+		return entities.BuyXGetY{ProductID: entry.ProductID, Buy: entry.Buy, Get: entry.Get}, nil
+	case RuleTypeMinBasket:
+		minimum, err := toMoney(entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Rule == nil {
+			return nil, fmt.Errorf("rule is required")
+		}
+		rule, err := toRule(entry.Rule)
+		if err != nil {
+			return nil, err
+		}
+		return entities.MinBasket{Min: minimum, Rule: rule}, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", entry.Type)
+	}
+}
+
+func toMoney(entry *ruleEntry) (entities.Money, error) {
+	if !entities.ValidCurrencyCode(entry.Currency) {
+		return entities.Money{}, fmt.Errorf("invalid currency %q", entry.Currency)
+	}
+	if entry.Amount <= 0 {
+		return entities.Money{}, fmt.Errorf("amount must be positive")
+	}
+	return entities.NewMoney(entry.Amount, entry.Currency), nil
+}
+
+func (p *StaticPromotions) GetPromotion(_ context.Context, code string) (*entities.Promotion, error) {
+	return p.promotions[strings.ToUpper(code)], nil
+}
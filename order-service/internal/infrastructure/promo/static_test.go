@@ -0,0 +1,76 @@
+package promo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePromotions(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "promotions.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticPromotions_GetPromotion(t *testing.T) {
+	promotions, err := NewStaticPromotions(writePromotions(t, `[
+		{"code": "welcome10", "rules": [{"type": "percentage", "percent": 10}]},
+		{"code": "BIGBASKET", "rules": [
+			{"type": "min_basket", "amount": 500000, "currency": "RUB",
+			 "rule": {"type": "fixed_amount", "amount": 50000, "currency": "RUB"}},
+			{"type": "buy_x_get_y", "product_id": "prod1", "buy": 2, "get": 1}
+		]}
+	]`))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	welcome, err := promotions.GetPromotion(ctx, "WELCOME10")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.Promotion{
+		Code:  "WELCOME10",
+		Rules: []entities.DiscountRule{entities.PercentageOff{Percent: 10}},
+	}, welcome)
+
+	bigBasket, err := promotions.GetPromotion(ctx, "BIGBASKET")
+	require.NoError(t, err)
+	assert.Equal(t, []entities.DiscountRule{
+		entities.MinBasket{
+			Min:  entities.NewMoney(500000, "RUB"),
+			Rule: entities.FixedAmountOff{Amount: entities.NewMoney(50000, "RUB")},
+		},
+		entities.BuyXGetY{ProductID: "prod1", Buy: 2, Get: 1},
+	}, bigBasket.Rules)
+
+	missing, err := promotions.GetPromotion(ctx, "NOPE")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestNewStaticPromotions_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":         `[{"code": "A"`,
+		"missing code":      `[{"rules": [{"type": "percentage", "percent": 10}]}]`,
+		"no rules":          `[{"code": "A"}]`,
+		"duplicate":         `[{"code": "a", "rules": [{"type": "percentage", "percent": 10}]}, {"code": "A", "rules": [{"type": "percentage", "percent": 5}]}]`,
+		"unknown type":      `[{"code": "A", "rules": [{"type": "free_shipping"}]}]`,
+		"percent too large": `[{"code": "A", "rules": [{"type": "percentage", "percent": 150}]}]`,
+		"no currency":       `[{"code": "A", "rules": [{"type": "fixed_amount", "amount": 100}]}]`,
+		"no product":        `[{"code": "A", "rules": [{"type": "buy_x_get_y", "buy": 2, "get": 1}]}]`,
+		"no nested rule":    `[{"code": "A", "rules": [{"type": "min_basket", "amount": 100, "currency": "RUB"}]}]`,
+		"invalid nested":    `[{"code": "A", "rules": [{"type": "min_basket", "amount": 100, "currency": "RUB", "rule": {"type": "percentage"}}]}]`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStaticPromotions(writePromotions(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
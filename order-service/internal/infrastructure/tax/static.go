@@ -0,0 +1,111 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"order-service/internal/domain/entities"
+)
+
+// wildcard in a rule's region or category matches any value.
+const wildcard = "*"
+
+// StaticRules levies tax according to rules read once at startup from a JSON
+// file.
+type StaticRules struct {
+	// rules is keyed by region, then by category.
+	rules map[string]map[string]entities.TaxRule
+}
+
+type ruleEntry struct {
+	Region   string `json:"region"`
+	Category string `json:"category"`
+	// Rate is a percentage with up to two decimal places, e.g. 7.25.
+	Rate      float64 `json:"rate"`
+	Inclusive bool    `json:"inclusive"`
+}
+
+// NewStaticRules loads the tax rules listed in the JSON file at path, e.g.
+//
+//	[{"region": "RU", "category": "*", "rate": 20, "inclusive": true},
+//	 {"region": "RU", "category": "food", "rate": 10, "inclusive": true},
+//	 {"region": "US-NY", "category": "*", "rate": 8.875}]
+//
+// "*" as a region or category matches any. A line is taxed by the most specific
+// rule: one for its region beats a "*" one, and then one for its category
+// beats a "*" one. Regions and categories are case-insensitive.
+func NewStaticRules(path string) (*StaticRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax rules: %w", err)
+	}
+
+	var entries []ruleEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse tax rules %s: %w", path, err)
+	}
+
+	rules := make(map[string]map[string]entities.TaxRule)
+	for i, entry := range entries {
+		region := strings.ToUpper(strings.TrimSpace(entry.Region))
+		category := normalizeCategory(entry.Category)
+		if region == "" || category == "" {
+			return nil, fmt.Errorf("tax rules %s: entry %d needs a region and a category", path, i)
+		}
+		if entry.Rate < 0 || entry.Rate > 100 {
+			return nil, fmt.Errorf("tax rules %s: entry %d: rate must be between 0 and 100", path, i)
+		}
+		rate := math.Round(entry.Rate * 100)
+		if math.Abs(rate-entry.Rate*100) > 1e-6 {
+			return nil, fmt.Errorf("tax rules %s: entry %d: rate must have at most two decimal places", path, i)
+		}
+		if _, ok := rules[region][category]; ok {
+			return nil, fmt.Errorf("tax rules %s: %s/%s is listed twice", path, region, category)
+		}
+
+		if rules[region] == nil {
+			rules[region] = make(map[string]entities.TaxRule)
+		}
+		rules[region][category] = entities.TaxRule{
+			Region:          region,
+			Category:        category,
+			RateBasisPoints: int64(rate),
+			Inclusive:       entry.Inclusive,
+		}
+	}
+
+	return &StaticRules{rules: rules}, nil
+}
+
+func normalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+func (r *StaticRules) CalculateTax(_ context.Context, region string, lines []entities.TaxableLine) ([]entities.ItemTax, error) {
+	region = strings.ToUpper(region)
+	taxes := make([]entities.ItemTax, len(lines))
+	for i, line := range lines {
+		rule, ok := r.rule(region, normalizeCategory(line.Category))
+		if !ok {
+			return nil, fmt.Errorf("%w for category %q in region %q", entities.ErrNoTaxRule, line.Category, region)
+		}
+		taxes[i] = rule.Tax(line.Amount)
+	}
+	return taxes, nil
+}
+
+// rule returns the most specific rule for category in region.
+func (r *StaticRules) rule(region, category string) (entities.TaxRule, bool) {
+	for _, reg := range []string{region, wildcard} {
+		for _, cat := range []string{category, wildcard} {
+			if rule, ok := r.rules[reg][cat]; ok {
+				return rule, true
+			}
+		}
+	}
+	return entities.TaxRule{}, false
+}
@@ -0,0 +1,80 @@
+package tax
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRules(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tax_rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticRules_CalculateTax(t *testing.T) {
+	rules, err := NewStaticRules(writeRules(t, `[
+		{"region": "ru", "category": "*", "rate": 20, "inclusive": true},
+		{"region": "RU", "category": "Food", "rate": 10, "inclusive": true},
+		{"region": "*", "category": "books", "rate": 0},
+		{"region": "US-CA", "category": "*", "rate": 7.25}
+	]`))
+	require.NoError(t, err)
+
+	taxes, err := rules.CalculateTax(context.Background(), "RU", []entities.TaxableLine{
+		{Category: "food", Amount: entities.NewMoney(1100, "RUB")},
+		{Category: "toys", Amount: entities.NewMoney(1200, "RUB")},
+		{Amount: entities.NewMoney(1200, "RUB")},
+		{Category: "books", Amount: entities.NewMoney(1200, "RUB")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ItemTax{
+		{RateBasisPoints: 1000, Inclusive: true, Amount: entities.NewMoney(100, "RUB")},
+		{RateBasisPoints: 2000, Inclusive: true, Amount: entities.NewMoney(200, "RUB")},
+		{RateBasisPoints: 2000, Inclusive: true, Amount: entities.NewMoney(200, "RUB")},
+		{RateBasisPoints: 2000, Inclusive: true, Amount: entities.NewMoney(200, "RUB")},
+	}, taxes, "a region's own rules beat wildcard regions")
+
+	taxes, err = rules.CalculateTax(context.Background(), "DE", []entities.TaxableLine{
+		{Category: "books", Amount: entities.NewMoney(1000, "EUR")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ItemTax{{Amount: entities.NewMoney(0, "EUR")}}, taxes)
+
+	taxes, err = rules.CalculateTax(context.Background(), "US-CA", []entities.TaxableLine{
+		{Category: "toys", Amount: entities.NewMoney(1999, "USD")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ItemTax{{RateBasisPoints: 725, Amount: entities.NewMoney(145, "USD")}}, taxes)
+
+	_, err = rules.CalculateTax(context.Background(), "DE", []entities.TaxableLine{
+		{Category: "toys", Amount: entities.NewMoney(1000, "EUR")},
+	})
+	assert.ErrorIs(t, err, entities.ErrNoTaxRule)
+}
+
+func TestNewStaticRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":      `[{"region": "RU"`,
+		"missing region": `[{"category": "*", "rate": 20}]`,
+		"missing cat":    `[{"region": "RU", "rate": 20}]`,
+		"negative rate":  `[{"region": "RU", "category": "*", "rate": -1}]`,
+		"rate too large": `[{"region": "RU", "category": "*", "rate": 120}]`,
+		"too precise":    `[{"region": "RU", "category": "*", "rate": 8.875}]`,
+		"duplicate":      `[{"region": "ru", "category": "*", "rate": 20}, {"region": "RU", "category": "*", "rate": 10}]`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewStaticRules(writeRules(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
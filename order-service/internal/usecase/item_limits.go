@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"order-service/internal/domain/entities"
+)
+
+// ItemLimits bounds the size of orders. Zero fields impose no limit.
+type ItemLimits struct {
+	// MaxLines is the number of distinct products an order may contain.
+	MaxLines int
+	// MaxQuantity is the quantity of a single product an order may contain.
+	MaxQuantity int
+	// MaxTotalQuantity is the number of units of all products together, not
+	// the amount of the order.
+	MaxTotalQuantity int
+}
+
+// WithItemLimits makes CreateOrder and item edits reject orders exceeding
+// limits. Orders are unbounded by default.
+func WithItemLimits(limits ItemLimits) Option {
+	return func(uc *OrderUseCase) {
+		uc.itemLimits = limits
+	}
+}
+
+// FieldViolation describes a problem with one field of a request. Field is
+// the path of the field, such as "items[2].quantity".
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError reports every problem found with a request rather than just
+// the first one. It wraps Err, such as ErrInvalidItem, which classifies it.
+type ValidationError struct {
+	Err        error
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		violations[i] = v.Field + ": " + v.Description
+	}
+	return fmt.Sprintf("%v: %s", e.Err, strings.Join(violations, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError reports a single violation classified by err.
+func fieldError(err error, field, description string) error {
+	return &ValidationError{Err: err, Violations: []FieldViolation{{field, description}}}
+}
+
+func itemField(i int, name string) string {
+	return fmt.Sprintf("items[%d].%s", i, name)
+}
+
+// validateItems checks each line of a new order on its own, before the
+// catalog is asked about the products.
+func validateItems(items []entities.Item) error {
+	var violations []FieldViolation
+	for i, item := range items {
+		if item.ProductID == "" {
+			violations = append(violations, FieldViolation{itemField(i, "product_id"), "is required"})
+		}
+		if item.Quantity <= 0 {
+			violations = append(violations, FieldViolation{itemField(i, "quantity"), "must be positive"})
+		}
+		if item.Price.IsNegative() {
+			violations = append(violations, FieldViolation{itemField(i, "unit_price"), "must not be negative"})
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Err: ErrInvalidItem, Violations: violations}
+	}
+	return nil
+}
+
+// mergeItems merges the lines of each product into the first of them, adding
+// up their quantities, so that warehouses see every product once. Lines of the
+// same product must agree on price and category. The request index of the
+// first line of each merged item is returned alongside.
+func mergeItems(items []entities.Item) ([]entities.Item, []int, error) {
+	merged := make([]entities.Item, 0, len(items))
+	firstLines := make([]int, 0, len(items))
+	byProduct := make(map[string]int, len(items))
+	var violations []FieldViolation
+
+	for i, item := range items {
+		j, ok := byProduct[item.ProductID]
+		if !ok {
+			byProduct[item.ProductID] = len(merged)
+			merged = append(merged, item)
+			firstLines = append(firstLines, i)
+			continue
+		}
+
+		switch {
+		case item.Price != merged[j].Price:
+			violations = append(violations, FieldViolation{itemField(i, "price"),
+				fmt.Sprintf("%s differs from %s in items[%d] for the same product", item.Price, merged[j].Price, firstLines[j])})
+		case item.Category != merged[j].Category:
+			violations = append(violations, FieldViolation{itemField(i, "category"),
+				fmt.Sprintf("%q differs from %q in items[%d] for the same product", item.Category, merged[j].Category, firstLines[j])})
+		}
+		merged[j].Quantity += item.Quantity
+	}
+
+	if len(violations) > 0 {
+		return nil, nil, &ValidationError{Err: ErrInvalidItem, Violations: violations}
+	}
+	return merged, firstLines, nil
+}
+
+// checkRequestLines rejects requests with more than MaxRequestLines lines.
+func checkRequestLines(items []entities.Item) error {
+	if len(items) > MaxRequestLines {
+		return &ValidationError{Err: ErrItemLimitExceeded, Violations: []FieldViolation{{"items",
+			fmt.Sprintf("has %d lines, at most %d are allowed", len(items), MaxRequestLines)}}}
+	}
+	return nil
+}
+
+// checkItemLimits checks items, one line per product, against the configured
+// limits. lines maps each item to the index its violations are reported at;
+// when nil, items are reported at their own index.
+func (uc *OrderUseCase) checkItemLimits(items []entities.Item, lines []int) error {
+	limits := uc.itemLimits
+	var violations []FieldViolation
+
+	if limits.MaxLines > 0 && len(items) > limits.MaxLines {
+		violations = append(violations, FieldViolation{"items",
+			fmt.Sprintf("has %d distinct products, at most %d are allowed", len(items), limits.MaxLines)})
+	}
+
+	totalQuantity := 0
+	for i, item := range items {
+		totalQuantity += item.Quantity
+		if limits.MaxQuantity > 0 && item.Quantity > limits.MaxQuantity {
+			line := i
+			if lines != nil {
+				line = lines[i]
+			}
+			violations = append(violations, FieldViolation{itemField(line, "quantity"),
+				fmt.Sprintf("%d units of %s ordered, at most %d are allowed", item.Quantity, item.ProductID, limits.MaxQuantity)})
+		}
+	}
+
+	if limits.MaxTotalQuantity > 0 && totalQuantity > limits.MaxTotalQuantity {
+		violations = append(violations, FieldViolation{"items",
+			fmt.Sprintf("has %d units in total, at most %d are allowed", totalQuantity, limits.MaxTotalQuantity)})
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Err: ErrItemLimitExceeded, Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderUseCase_CreateOrder_MergesDuplicateItems(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 2, Price: rub(1000)},
+			{ProductID: "prod2", Quantity: 1, Price: rub(500)},
+			{ProductID: "prod1", Quantity: 3, Price: rub(1000)},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Item{
+		{ProductID: "prod1", Quantity: 5, Price: rub(1000)},
+		{ProductID: "prod2", Quantity: 1, Price: rub(500)},
+	}, order.Items)
+	assert.Equal(t, rub(5500), order.TotalAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_ConflictingDuplicateItems(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	_, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 2, Price: rub(1000)},
+			{ProductID: "prod1", Quantity: 1, Price: rub(900)},
+			{ProductID: "prod1", Quantity: 1, Price: rub(1000), Category: "books"},
+		},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidItem)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldViolation{
+		{"items[1].price", "9.00 RUB differs from 10.00 RUB in items[0] for the same product"},
+		{"items[2].category", `"books" differs from "" in items[0] for the same product`},
+	}, validationErr.Violations)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_CreateOrder_ReportsEveryInvalidItem(t *testing.T) {
+	useCase := NewOrderUseCase(new(repositorytest.MockOrderRepository))
+
+	_, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items: []entities.Item{
+			{ProductID: "", Quantity: 1, Price: rub(1000)},
+			{ProductID: "prod2", Quantity: 1, Price: rub(500)},
+			{ProductID: "prod3", Quantity: -1, Price: rub(500)},
+		},
+	})
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ErrorIs(t, err, ErrInvalidItem)
+	assert.Equal(t, []FieldViolation{
+		{"items[0].product_id", "is required"},
+		{"items[2].quantity", "must be positive"},
+	}, validationErr.Violations)
+}
+
+func TestOrderUseCase_CreateOrder_ItemLimits(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo, WithItemLimits(ItemLimits{MaxLines: 2, MaxQuantity: 10, MaxTotalQuantity: 15}))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		items []entities.Item
+		want  []FieldViolation
+	}{
+		{
+			name: "too many lines",
+			items: []entities.Item{
+				{ProductID: "prod1", Quantity: 1, Price: rub(100)},
+				{ProductID: "prod2", Quantity: 1, Price: rub(100)},
+				{ProductID: "prod3", Quantity: 1, Price: rub(100)},
+			},
+			want: []FieldViolation{{"items", "has 3 distinct products, at most 2 are allowed"}},
+		},
+		{
+			name: "quantity of merged lines",
+			items: []entities.Item{
+				{ProductID: "prod1", Quantity: 1, Price: rub(100)},
+				{ProductID: "prod2", Quantity: 6, Price: rub(100)},
+				{ProductID: "prod2", Quantity: 6, Price: rub(100)},
+			},
+			want: []FieldViolation{{"items[1].quantity", "12 units of prod2 ordered, at most 10 are allowed"}},
+		},
+		{
+			name: "total quantity",
+			items: []entities.Item{
+				{ProductID: "prod1", Quantity: 8, Price: rub(100)},
+				{ProductID: "prod2", Quantity: 8, Price: rub(100)},
+			},
+			want: []FieldViolation{{"items", "has 16 units in total, at most 15 are allowed"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: tt.items})
+
+			assert.ErrorIs(t, err, ErrItemLimitExceeded)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.want, validationErr.Violations)
+		})
+	}
+
+	// Duplicate lines count as one product towards MaxLines.
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).Once()
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 5, Price: rub(100)},
+			{ProductID: "prod2", Quantity: 5, Price: rub(100)},
+			{ProductID: "prod1", Quantity: 5, Price: rub(100)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, order.Items, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_TooManyRequestLines(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	catalog := new(MockProductCatalog)
+
+	useCase := NewOrderUseCase(mockRepo, WithProductCatalog(catalog, PricePolicyOverride))
+
+	// The lines would merge into one, but are rejected before being priced.
+	items := make([]entities.Item, MaxRequestLines+1)
+	for i := range items {
+		items[i] = entities.Item{ProductID: "prod1", Quantity: 1}
+	}
+
+	_, err := useCase.CreateOrder(context.Background(), CreateOrderInput{UserID: "user123", Items: items})
+
+	assert.ErrorIs(t, err, ErrItemLimitExceeded)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldViolation{{"items", "has 1001 lines, at most 1000 are allowed"}}, validationErr.Violations)
+	catalog.AssertNotCalled(t, "GetProducts", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_CreateOrder_IdempotencyKeyAfterLimitsTightened(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	ctx := context.Background()
+	input := CreateOrderInput{
+		UserID:         "user123",
+		Items:          []entities.Item{{ProductID: "prod1", Quantity: 5, Price: rub(100)}},
+		IdempotencyKey: "key-1",
+	}
+
+	var created *entities.Order
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").
+		Return((*entities.Order)(nil), repositories.ErrOrderNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entities.Order) }).Once()
+
+	first, err := NewOrderUseCase(mockRepo).CreateOrder(ctx, input)
+	require.NoError(t, err)
+
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(created, nil)
+
+	replayed, err := NewOrderUseCase(mockRepo, WithItemLimits(ItemLimits{MaxQuantity: 1})).CreateOrder(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, first.OrderID, replayed.OrderID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_EditItems_ItemLimits(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo, WithItemLimits(ItemLimits{MaxLines: 2, MaxQuantity: 10}))
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(editableOrder(), nil)
+
+	_, err := useCase.AddItem(ctx, AddItemInput{
+		OrderID: "test-order",
+		Item:    entities.Item{ProductID: "prod3", Quantity: 1, Price: rub(100)},
+	})
+	assert.ErrorIs(t, err, ErrItemLimitExceeded)
+
+	_, err = useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod2", Quantity: 11})
+	assert.ErrorIs(t, err, ErrItemLimitExceeded)
+
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
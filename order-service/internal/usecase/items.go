@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+// AddItemInput describes a line to add to an order. An Item.Price without a
+// currency is taken to be in the order currency. If ExpectedVersion is non-nil
+// the edit only succeeds while the order is still at that version.
+type AddItemInput struct {
+	OrderID         string
+	Item            entities.Item
+	ExpectedVersion *int64
+}
+
+// RemoveItemInput names the product whose line to remove from an order.
+type RemoveItemInput struct {
+	OrderID         string
+	ProductID       string
+	ExpectedVersion *int64
+}
+
+// ChangeItemQuantityInput sets the quantity of the line of a product.
+type ChangeItemQuantityInput struct {
+	OrderID         string
+	ProductID       string
+	Quantity        int
+	ExpectedVersion *int64
+}
+
+// AddItem adds a line for a product that is not in the order yet; the
+// quantity of products already in it is changed with ChangeItemQuantity. The
+// new item is checked against the product catalog like those of CreateOrder.
+func (uc *OrderUseCase) AddItem(ctx context.Context, input AddItemInput) (*entities.Order, error) {
+	item := input.Item
+	if item.ProductID == "" {
+		return nil, fmt.Errorf("%w: item has no product ID", ErrInvalidItem)
+	}
+	if item.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: item has invalid quantity", ErrInvalidItem)
+	}
+	item.Tax = nil
+
+	return uc.editItems(ctx, input.OrderID, input.ExpectedVersion, func(order *entities.Order, items []entities.Item) ([]entities.Item, error) {
+		if findItem(items, item.ProductID) >= 0 {
+			return nil, fmt.Errorf("%w: %s is already in the order, change its quantity instead", ErrInvalidItem, item.ProductID)
+		}
+		if item.Price.Currency == "" {
+			item.Price.Currency = order.Currency
+		}
+
+		priced, err := uc.priceItems(ctx, []entities.Item{item})
+		if err != nil {
+			return nil, err
+		}
+		return append(items, priced[0]), nil
+	})
+}
+
+// RemoveItem removes the line of a product from an order. The last line
+// cannot be removed; the order is cancelled instead.
+func (uc *OrderUseCase) RemoveItem(ctx context.Context, input RemoveItemInput) (*entities.Order, error) {
+	return uc.editItems(ctx, input.OrderID, input.ExpectedVersion, func(order *entities.Order, items []entities.Item) ([]entities.Item, error) {
+		i := findItem(items, input.ProductID)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrItemNotFound, input.ProductID)
+		}
+		if len(items) == 1 {
+			return nil, fmt.Errorf("%w: cannot remove the last item, cancel the order instead", ErrEmptyItems)
+		}
+		return append(items[:i], items[i+1:]...), nil
+	})
+}
+
+// ChangeItemQuantity sets the quantity of the line of a product; the line
+// keeps the price the product was ordered at.
+func (uc *OrderUseCase) ChangeItemQuantity(ctx context.Context, input ChangeItemQuantityInput) (*entities.Order, error) {
+	if input.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive, remove the item instead", ErrInvalidItem)
+	}
+
+	return uc.editItems(ctx, input.OrderID, input.ExpectedVersion, func(order *entities.Order, items []entities.Item) ([]entities.Item, error) {
+		i := findItem(items, input.ProductID)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrItemNotFound, input.ProductID)
+		}
+		items[i].Quantity = input.Quantity
+		return items, nil
+	})
+}
+
+// findItem returns the index of the first line of productID in items, or -1.
+func findItem(items []entities.Item, productID string) int {
+	for i, item := range items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+	return -1
+}
+
+// editItems applies edit to a copy of the items of a pending order, works out
+// the amounts of the result like CreateOrder does, with the order's promo code
+// and region, and stores it with an order.updated event. Items already in the
+// order keep their prices. An edit after which the promo code no longer
+// applies or the order exceeds the item limits is rejected.
+func (uc *OrderUseCase) editItems(ctx context.Context, orderID string, expectedVersion *int64, edit func(order *entities.Order, items []entities.Item) ([]entities.Item, error)) (*entities.Order, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order for item update: %w", err)
+	}
+
+	if expectedVersion != nil && *expectedVersion != order.Version {
+		return nil, fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, *expectedVersion, order.Version)
+	}
+	if order.Status != string(entities.OrderStatusPending) {
+		return nil, fmt.Errorf("%w: order is %s", ErrItemsNotEditable, order.Status)
+	}
+
+	// The tax is worked out afresh for the edited items.
+	items := make([]entities.Item, len(order.Items))
+	for i, item := range order.Items {
+		item.Tax = nil
+		items[i] = item
+	}
+	items, err = edit(order, items)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkItemLimits(items, nil); err != nil {
+		return nil, err
+	}
+
+	amounts, err := uc.calculateAmounts(ctx, order.Currency, order.PromoCode, order.Region, items)
+	if err != nil {
+		return nil, err
+	}
+
+	update := entities.ItemsUpdate{
+		Items:       amounts.items,
+		Subtotal:    amounts.subtotal,
+		Discounts:   amounts.discounts,
+		TaxAmount:   amounts.tax,
+		TotalAmount: amounts.total,
+		UpdatedAt:   time.Now(),
+	}
+
+	currentVersion := order.Version
+	order.Items = update.Items
+	order.Subtotal = update.Subtotal
+	order.Discounts = update.Discounts
+	order.TaxAmount = update.TaxAmount
+	order.TotalAmount = update.TotalAmount
+	order.UpdatedAt = update.UpdatedAt
+	order.Version = currentVersion + 1
+
+	event := newOrderEvent(ctx, entities.OrderEventUpdated, order)
+	if err := uc.orderRepo.UpdateItems(ctx, order.OrderID, update, currentVersion, event); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, fmt.Errorf("%w: order was modified concurrently", ErrVersionConflict)
+		}
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+
+	uc.logger.InfoContext(ctx, "Order items updated",
+		"order_id", order.OrderID,
+		"total", order.TotalAmount.String(),
+		"items", len(order.Items),
+		"version", order.Version)
+
+	return order, nil
+}
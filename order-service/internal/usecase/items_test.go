@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// editableOrder returns a pending order of two lines worth 2500 at version 2.
+func editableOrder() *entities.Order {
+	return &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 2, Price: rub(1000)},
+			{ProductID: "prod2", Quantity: 1, Price: rub(500)},
+		},
+		Currency:    "RUB",
+		Subtotal:    rub(2500),
+		TaxAmount:   rub(0),
+		TotalAmount: rub(2500),
+		Status:      "PENDING",
+		Version:     2,
+	}
+}
+
+func TestOrderUseCase_AddItem(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo, WithProductCatalog(testCatalog(), PricePolicyOverride))
+	ctx := context.Background()
+
+	existing := editableOrder()
+	existing.Items = []entities.Item{{ProductID: "prod2", Quantity: 5, Price: rub(500)}}
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existing, nil)
+	mockRepo.On("UpdateItems", mock.Anything, "test-order", mock.MatchedBy(func(u entities.ItemsUpdate) bool {
+		return len(u.Items) == 2 && u.Subtotal == rub(3500) && u.TotalAmount == rub(3500) && !u.UpdatedAt.IsZero()
+	}), int64(2), mock.MatchedBy(func(e *entities.OrderEvent) bool {
+		return e.Type == entities.OrderEventUpdated && e.Order.Version == 3 && len(e.Order.Items) == 2
+	})).Return(nil)
+
+	// The catalog prices the new line.
+	order, err := useCase.AddItem(ctx, AddItemInput{
+		OrderID: "test-order",
+		Item:    entities.Item{ProductID: "prod1", Quantity: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entities.Item{ProductID: "prod1", Quantity: 1, Price: rub(1000), Category: "books"}, order.Items[1])
+	assert.Equal(t, rub(3500), order.TotalAmount)
+	assert.Equal(t, int64(3), order.Version)
+
+	_, err = useCase.AddItem(ctx, AddItemInput{
+		OrderID: "test-order",
+		Item:    entities.Item{ProductID: "sold-out", Quantity: 1},
+	})
+	assert.ErrorIs(t, err, ErrProductUnavailable)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "UpdateItems", 1)
+}
+
+func TestOrderUseCase_AddItem_InvalidInput(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(editableOrder(), nil)
+
+	tests := []struct {
+		name    string
+		input   AddItemInput
+		wantErr error
+	}{
+		{
+			name:    "empty order id",
+			input:   AddItemInput{Item: entities.Item{ProductID: "prod3", Quantity: 1, Price: rub(100)}},
+			wantErr: ErrInvalidOrderID,
+		},
+		{
+			name:    "no product id",
+			input:   AddItemInput{OrderID: "test-order", Item: entities.Item{Quantity: 1, Price: rub(100)}},
+			wantErr: ErrInvalidItem,
+		},
+		{
+			name:    "zero quantity",
+			input:   AddItemInput{OrderID: "test-order", Item: entities.Item{ProductID: "prod3", Price: rub(100)}},
+			wantErr: ErrInvalidItem,
+		},
+		{
+			name:    "product already in the order",
+			input:   AddItemInput{OrderID: "test-order", Item: entities.Item{ProductID: "prod2", Quantity: 1, Price: rub(500)}},
+			wantErr: ErrInvalidItem,
+		},
+		{
+			name:    "other currency",
+			input:   AddItemInput{OrderID: "test-order", Item: entities.Item{ProductID: "prod3", Quantity: 1, Price: entities.NewMoney(100, "EUR")}},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := useCase.AddItem(ctx, tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, order)
+		})
+	}
+
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_RemoveItem(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(editableOrder(), nil)
+	mockRepo.On("UpdateItems", mock.Anything, "test-order", mock.AnythingOfType("entities.ItemsUpdate"), int64(2), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.RemoveItem(ctx, RemoveItemInput{OrderID: "test-order", ProductID: "prod1"})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Item{{ProductID: "prod2", Quantity: 1, Price: rub(500)}}, order.Items)
+	assert.Equal(t, rub(500), order.Subtotal)
+	assert.Equal(t, rub(500), order.TotalAmount)
+
+	_, err = useCase.RemoveItem(ctx, RemoveItemInput{OrderID: "test-order", ProductID: "missing"})
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	mockRepo.AssertNumberOfCalls(t, "UpdateItems", 1)
+}
+
+func TestOrderUseCase_RemoveItem_LastItem(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	order := editableOrder()
+	order.Items = order.Items[:1]
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(order, nil)
+
+	_, err := useCase.RemoveItem(context.Background(), RemoveItemInput{OrderID: "test-order", ProductID: "prod1"})
+	assert.ErrorIs(t, err, ErrEmptyItems)
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_ChangeItemQuantity_RecalculatesDiscountAndTax(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "BIG10").Return(&entities.Promotion{
+		Code:  "BIG10",
+		Rules: []entities.DiscountRule{entities.MinBasket{Min: rub(2000), Rule: entities.PercentageOff{Percent: 10}}},
+	}, nil)
+
+	calculator := new(MockTaxCalculator)
+	calculator.On("CalculateTax", mock.Anything, "RU", []entities.TaxableLine{
+		{Amount: rub(4500)},
+		{Amount: rub(450)},
+	}).Return([]entities.ItemTax{
+		{RateBasisPoints: 2000, Amount: rub(900)},
+		{RateBasisPoints: 2000, Amount: rub(90)},
+	}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions), WithTaxCalculator(calculator))
+	ctx := context.Background()
+
+	// The stored tax is stale and must not leak into the edited order.
+	existing := editableOrder()
+	existing.PromoCode = "BIG10"
+	existing.Region = "RU"
+	existing.Items[0].Tax = &entities.ItemTax{RateBasisPoints: 2000, Amount: rub(360)}
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existing, nil)
+	mockRepo.On("UpdateItems", mock.Anything, "test-order", mock.AnythingOfType("entities.ItemsUpdate"), int64(2), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod1", Quantity: 5})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, order.Items[0].Quantity)
+	assert.Equal(t, rub(5500), order.Subtotal)
+	assert.Equal(t, []entities.Discount{{PromoCode: "BIG10", Description: "10% off on orders from 20.00 RUB", Amount: rub(550)}}, order.Discounts)
+	assert.Equal(t, rub(990), order.TaxAmount)
+	assert.Equal(t, rub(5940), order.TotalAmount)
+	assert.Equal(t, rub(900), order.Items[0].Tax.Amount)
+
+	calculator.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_ChangeItemQuantity_PromoNoLongerApplies(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "BIG10").Return(&entities.Promotion{
+		Code:  "BIG10",
+		Rules: []entities.DiscountRule{entities.MinBasket{Min: rub(2000), Rule: entities.PercentageOff{Percent: 10}}},
+	}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions))
+
+	existing := editableOrder()
+	existing.PromoCode = "BIG10"
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existing, nil)
+
+	order, err := useCase.ChangeItemQuantity(context.Background(), ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod1", Quantity: 1})
+	assert.ErrorIs(t, err, ErrPromoCodeNotApplicable)
+	assert.Nil(t, order)
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_ChangeItemQuantity_InvalidInput(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(editableOrder(), nil)
+
+	_, err := useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod1"})
+	assert.ErrorIs(t, err, ErrInvalidItem)
+
+	_, err = useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "missing", Quantity: 1})
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_EditItems_NotPending(t *testing.T) {
+	for _, status := range []string{"PAID", "FAILED", "CANCELLED", "REFUNDED"} {
+		t.Run(status, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+
+			useCase := NewOrderUseCase(mockRepo)
+			ctx := context.Background()
+
+			order := editableOrder()
+			order.Status = status
+			mockRepo.On("GetByID", mock.Anything, "test-order").Return(order, nil)
+
+			_, err := useCase.AddItem(ctx, AddItemInput{OrderID: "test-order", Item: entities.Item{ProductID: "prod3", Quantity: 1, Price: rub(100)}})
+			assert.ErrorIs(t, err, ErrItemsNotEditable)
+			_, err = useCase.RemoveItem(ctx, RemoveItemInput{OrderID: "test-order", ProductID: "prod1"})
+			assert.ErrorIs(t, err, ErrItemsNotEditable)
+			_, err = useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod1", Quantity: 3})
+			assert.ErrorIs(t, err, ErrItemsNotEditable)
+
+			mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_EditItems_VersionConflict(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(editableOrder(), nil)
+
+	stale := int64(1)
+	order, err := useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{
+		OrderID:         "test-order",
+		ProductID:       "prod1",
+		Quantity:        3,
+		ExpectedVersion: &stale,
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+	mockRepo.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The order changes between the read and the write.
+	mockRepo.On("UpdateItems", mock.Anything, "test-order", mock.Anything, int64(2), mock.Anything).Return(repositories.ErrVersionConflict)
+
+	order, err = useCase.ChangeItemQuantity(ctx, ChangeItemQuantityInput{OrderID: "test-order", ProductID: "prod1", Quantity: 3})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+}
@@ -2,80 +2,409 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"order-service/internal/domain/entities"
 	"order-service/internal/domain/repositories"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
+// NatsPublisher delivers order events to other services. OrderUseCase never calls
+// it directly: events are recorded in the outbox together with the order change
+// and handed to the publisher by the outbox relay.
 type NatsPublisher interface {
 	PublishOrderCreated(ctx context.Context, order *entities.Order) error
+	// PublishOrderStatusChanged announces that order moved from previousStatus
+	// to its current status at changedAt. Every transition is announced with
+	// it, cancellations included.
+	PublishOrderStatusChanged(ctx context.Context, order *entities.Order, previousStatus string, changedAt time.Time) error
+	// PublishOrderCancelled announces that order was cancelled from previousStatus;
+	// the details are in order.Cancellation. It comes on top of the status
+	// change for orders cancelled with CancelOrder.
+	PublishOrderCancelled(ctx context.Context, order *entities.Order, previousStatus string) error
+	// PublishShippingUpdated announces the new shipping details of order.
+	PublishShippingUpdated(ctx context.Context, order *entities.Order) error
+	// PublishOrderUpdated announces the new items and amounts of order.
+	PublishOrderUpdated(ctx context.Context, order *entities.Order) error
 	Close()
 }
 
+// OrderMetrics records business metrics about committed order changes.
+type OrderMetrics interface {
+	OrderCreated(order *entities.Order)
+	OrderStatusChanged(order *entities.Order, previousStatus string)
+}
+
 type OrderUseCase struct {
-	orderRepo     repositories.OrderRepository
-	natsPublisher NatsPublisher
+	orderRepo repositories.OrderRepository
+	// supportedCurrencies restricts the currencies orders may be placed in.
+	// Any well-formed currency code is accepted when it is empty.
+	supportedCurrencies map[string]bool
+	// catalog, when set, is the source of truth for products and their prices.
+	catalog     ProductCatalog
+	pricePolicy PricePolicy
+	promotions  PromotionCatalog
+	// taxCalculator, when set, levies tax on new orders.
+	taxCalculator TaxCalculator
+	itemLimits    ItemLimits
+	metrics       OrderMetrics
+	logger        *slog.Logger
 }
 
-func NewOrderUseCase(orderRepo repositories.OrderRepository, natsPublisher NatsPublisher) *OrderUseCase {
-	return &OrderUseCase{
-		orderRepo:     orderRepo,
-		natsPublisher: natsPublisher,
+type Option func(*OrderUseCase)
+
+// WithSupportedCurrencies restricts orders to the given ISO 4217 currency codes.
+func WithSupportedCurrencies(currencies ...string) Option {
+	return func(uc *OrderUseCase) {
+		uc.supportedCurrencies = make(map[string]bool, len(currencies))
+		for _, c := range currencies {
+			uc.supportedCurrencies[c] = true
+		}
 	}
 }
 
-func (uc *OrderUseCase) CreateOrder(ctx context.Context, userID string, items []entities.Item) (*entities.Order, error) {
-	if userID == "" {
-		return nil, ErrInvalidUserID
+// WithMetrics reports created orders and status changes to m.
+func WithMetrics(m OrderMetrics) Option {
+	return func(uc *OrderUseCase) {
+		uc.metrics = m
 	}
-	if len(items) == 0 {
-		return nil, ErrEmptyItems
+}
+
+// WithLogger logs order changes to l. Nothing is logged by default.
+func WithLogger(l *slog.Logger) Option {
+	return func(uc *OrderUseCase) {
+		uc.logger = l
 	}
+}
 
-	totalAmount := 0.0
-	for i, item := range items {
-		if item.Quantity <= 0 {
-			return nil, fmt.Errorf("%w: item %d has invalid quantity", ErrInvalidItem, i)
+func NewOrderUseCase(orderRepo repositories.OrderRepository, opts ...Option) *OrderUseCase {
+	uc := &OrderUseCase{
+		orderRepo: orderRepo,
+		metrics:   noopOrderMetrics{},
+		logger:    slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+type noopOrderMetrics struct{}
+
+func (noopOrderMetrics) OrderCreated(*entities.Order) {}
+
+func (noopOrderMetrics) OrderStatusChanged(*entities.Order, string) {}
+
+// CreateOrderInput describes an order to create. An empty Currency means
+// entities.DefaultCurrency; every item must be priced in the order currency.
+// With a product catalog configured, item prices are checked against or
+// replaced by the catalog prices, see WithProductCatalog. PromoCode is
+// optional and case-insensitive. Region, e.g. "RU" or "DE-BY", selects the
+// tax rules when a tax calculator is configured. ShippingAddress and
+// DeliveryMethod are checked as described at validateShipping. Several items
+// of the same product are merged into one; see mergeItems.
+type CreateOrderInput struct {
+	UserID          string
+	Currency        string
+	Items           []entities.Item
+	IdempotencyKey  string
+	PromoCode       string
+	Region          string
+	ShippingAddress *entities.ShippingAddress
+	DeliveryMethod  entities.DeliveryMethod
+}
+
+// CreateOrder creates a PENDING order. When IdempotencyKey is set and the user
+// already created an order with it, that order is returned instead, provided the
+// request payload is the same; otherwise ErrIdempotencyKeyReused is returned.
+func (uc *OrderUseCase) CreateOrder(ctx context.Context, input CreateOrderInput) (*entities.Order, error) {
+	if input.UserID == "" {
+		return nil, fieldError(ErrInvalidUserID, "user_id", "is required")
+	}
+	if len(input.Items) == 0 {
+		return nil, fieldError(ErrEmptyItems, "items", "must not be empty")
+	}
+	if err := validateItems(input.Items); err != nil {
+		return nil, err
+	}
+	if len(input.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return nil, fieldError(ErrInvalidIdempotencyKey, "idempotency_key",
+			fmt.Sprintf("is longer than %d characters", MaxIdempotencyKeyLength))
+	}
+	if len(input.PromoCode) > MaxPromoCodeLength {
+		return nil, fieldError(ErrUnknownPromoCode, "promo_code",
+			fmt.Sprintf("is longer than %d characters", MaxPromoCodeLength))
+	}
+	shippingAddress, deliveryMethod, err := validateShipping(input.ShippingAddress, input.DeliveryMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Currency == "" {
+		input.Currency = entities.DefaultCurrency
+	}
+	input.PromoCode = normalizePromoCode(input.PromoCode)
+	input.Region = normalizeRegion(input.Region)
+	input.ShippingAddress, input.DeliveryMethod = shippingAddress, deliveryMethod
+
+	// The hash covers the request as sent, once normalized, so that a retry
+	// spelling the promo code or region differently still matches. Retries are
+	// answered before the catalog, promotions and tax rules are consulted, so
+	// they get the order created earlier even if prices or availability have
+	// changed since, or the catalog is down.
+	var requestHash string
+	if input.IdempotencyKey != "" {
+		var err error
+		requestHash, err = hashCreateRequest(input)
+		if err != nil {
+			return nil, err
 		}
-		if item.Price < 0 {
-			return nil, fmt.Errorf("%w: item %d has invalid price", ErrInvalidItem, i)
+
+		existing, err := uc.findByIdempotencyKey(ctx, input.UserID, input.IdempotencyKey, requestHash)
+		if err != nil || existing != nil {
+			if existing != nil {
+				uc.logger.InfoContext(ctx, "Returning order created earlier with the same idempotency key", "order_id", existing.OrderID)
+			}
+			return existing, err
 		}
-		totalAmount += float64(item.Quantity) * item.Price
 	}
 
+	// Bound the request before the catalog is asked about every line of it.
+	if err := checkRequestLines(input.Items); err != nil {
+		return nil, err
+	}
+	priced, err := uc.priceItems(ctx, input.Items)
+	if err != nil {
+		return nil, err
+	}
+	// Checked before merging so that mismatches point at the request lines.
+	if err := uc.validateCurrency(input.Currency, priced); err != nil {
+		return nil, err
+	}
+	items, firstLines, err := mergeItems(priced)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkItemLimits(items, firstLines); err != nil {
+		return nil, err
+	}
+
+	amounts, err := uc.calculateAmounts(ctx, input.Currency, input.PromoCode, input.Region, items)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	order := &entities.Order{
-		OrderID:     uuid.New().String(),
-		UserID:      userID,
-		Items:       items,
-		TotalAmount: totalAmount,
-		Status:      string(entities.OrderStatusPending),
-		CreatedAt:   time.Now(),
+		OrderID:         uuid.New().String(),
+		UserID:          input.UserID,
+		Items:           amounts.items,
+		Currency:        input.Currency,
+		Subtotal:        amounts.subtotal,
+		PromoCode:       input.PromoCode,
+		Discounts:       amounts.discounts,
+		Region:          input.Region,
+		TaxAmount:       amounts.tax,
+		TotalAmount:     amounts.total,
+		ShippingAddress: input.ShippingAddress,
+		DeliveryMethod:  input.DeliveryMethod,
+		Status:          string(entities.OrderStatusPending),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Version:         1,
+		IdempotencyKey:  input.IdempotencyKey,
+		RequestHash:     requestHash,
 	}
 
-	if err := uc.orderRepo.Create(ctx, order); err != nil {
+	if err := uc.orderRepo.Create(ctx, order, newOrderEvent(ctx, entities.OrderEventCreated, order)); err != nil {
+		if input.IdempotencyKey != "" && errors.Is(err, repositories.ErrOrderAlreadyExists) {
+			// A concurrent request with the same key got there first.
+			existing, findErr := uc.findByIdempotencyKey(ctx, input.UserID, input.IdempotencyKey, requestHash)
+			if findErr != nil || existing != nil {
+				return existing, findErr
+			}
+		}
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
-	if uc.natsPublisher != nil {
-		go func() {
-			pubCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
+	uc.metrics.OrderCreated(order)
+	uc.logger.InfoContext(ctx, "Order created",
+		"order_id", order.OrderID,
+		"total", order.TotalAmount.String(),
+		"promo_code", order.PromoCode,
+		"items", len(order.Items))
 
-			if err := uc.natsPublisher.PublishOrderCreated(pubCtx, order); err != nil {
-				// Log error but don't fail the request
-				fmt.Printf("Warning: Failed to publish order.created event: %v\n", err)
-			}
-		}()
+	return order, nil
+}
+
+// orderAmounts are the amounts of an order worked out from its items.
+type orderAmounts struct {
+	// items are the order items with their tax set.
+	items     []entities.Item
+	subtotal  entities.Money
+	discounts []entities.Discount
+	tax       entities.Money
+	total     entities.Money
+}
+
+// calculateAmounts works out the subtotal of items priced in currency, the
+// discounts of the promotion behind promoCode, the tax levied in region and
+// the total to pay. Edited orders are recalculated with it too, so that they
+// add up exactly like new ones.
+func (uc *OrderUseCase) calculateAmounts(ctx context.Context, currency, promoCode, region string, items []entities.Item) (*orderAmounts, error) {
+	if err := uc.validateCurrency(currency, items); err != nil {
+		return nil, err
+	}
+
+	subtotal, err := calculateTotal(currency, items)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, total, err := uc.applyPromotion(ctx, promoCode, items, subtotal)
+	if err != nil {
+		return nil, err
+	}
+
+	tax, err := uc.applyTax(ctx, region, items, discounts, currency)
+	if err != nil {
+		return nil, err
+	}
+	total, err = total.Add(tax.exclusive)
+	if err != nil {
+		return nil, fmt.Errorf("%w: total with tax: %v", ErrInvalidItem, err)
+	}
+
+	return &orderAmounts{
+		items:     tax.items,
+		subtotal:  subtotal,
+		discounts: discounts,
+		tax:       tax.total,
+		total:     total,
+	}, nil
+}
+
+// validateCurrency checks that currency may be used and that every item is
+// priced in it, reporting each item that is not.
+func (uc *OrderUseCase) validateCurrency(currency string, items []entities.Item) error {
+	if !entities.ValidCurrencyCode(currency) {
+		return fieldError(ErrInvalidCurrency, "currency", fmt.Sprintf("%q is not an ISO 4217 code", currency))
+	}
+	if len(uc.supportedCurrencies) > 0 && !uc.supportedCurrencies[currency] {
+		return fieldError(ErrInvalidCurrency, "currency", fmt.Sprintf("%s is not supported", currency))
+	}
+
+	var violations []FieldViolation
+	for i, item := range items {
+		if item.Price.Currency != currency {
+			violations = append(violations, FieldViolation{itemField(i, "unit_price"),
+				fmt.Sprintf("is priced in %s, order is in %s", item.Price.Currency, currency)})
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Err: ErrCurrencyMismatch, Violations: violations}
+	}
+	return nil
+}
+
+// calculateTotal validates items and sums their prices exactly, in minor units.
+func calculateTotal(currency string, items []entities.Item) (entities.Money, error) {
+	total := entities.NewMoney(0, currency)
+	for i, item := range items {
+		if item.Quantity <= 0 {
+			return entities.Money{}, fmt.Errorf("%w: item %d has invalid quantity", ErrInvalidItem, i)
+		}
+		if item.Price.IsNegative() {
+			return entities.Money{}, fmt.Errorf("%w: item %d has invalid price", ErrInvalidItem, i)
+		}
+
+		line, err := item.Price.Mul(int64(item.Quantity))
+		if err != nil {
+			return entities.Money{}, fmt.Errorf("%w: item %d: %v", ErrInvalidItem, i, err)
+		}
+		total, err = total.Add(line)
+		if err != nil {
+			return entities.Money{}, fmt.Errorf("%w: item %d: %v", ErrInvalidItem, i, err)
+		}
+	}
+	return total, nil
+}
+
+// findByIdempotencyKey returns the order previously created with key, or nil if
+// there is none.
+func (uc *OrderUseCase) findByIdempotencyKey(ctx context.Context, userID, key, requestHash string) (*entities.Order, error) {
+	order, err := uc.orderRepo.GetByIdempotencyKey(ctx, userID, key)
+	if errors.Is(err, repositories.ErrOrderNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+
+	if order.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
 	}
 
 	return order, nil
 }
 
+func hashCreateRequest(input CreateOrderInput) (string, error) {
+	// Orders placed before currencies existed were all in the default
+	// currency and hashed without one; requests in it keep that hash.
+	currency := input.Currency
+	if currency == entities.DefaultCurrency {
+		currency = ""
+	}
+
+	data, err := json.Marshal(struct {
+		UserID   string          `json:"user_id"`
+		Currency string          `json:"currency,omitempty"`
+		Items    []entities.Item `json:"items"`
+		// Omitted when empty so that requests without them keep the hash they
+		// had before promo codes, regions and shipping details existed.
+		PromoCode       string                    `json:"promo_code,omitempty"`
+		Region          string                    `json:"region,omitempty"`
+		ShippingAddress *entities.ShippingAddress `json:"shipping_address,omitempty"`
+		DeliveryMethod  entities.DeliveryMethod   `json:"delivery_method,omitempty"`
+	}{input.UserID, currency, input.Items, input.PromoCode, input.Region, input.ShippingAddress, input.DeliveryMethod})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newOrderEvent snapshots order into an event, capturing the trace context of
+// ctx so the asynchronous delivery can be linked to the current request.
+func newOrderEvent(ctx context.Context, eventType entities.OrderEventType, order *entities.Order) *entities.OrderEvent {
+	snapshot := *order
+	event := &entities.OrderEvent{
+		EventID:    uuid.New().String(),
+		Type:       eventType,
+		Order:      &snapshot,
+		OccurredAt: time.Now(),
+	}
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) > 0 {
+		event.TraceContext = carrier
+	}
+
+	return event
+}
+
 func (uc *OrderUseCase) GetOrder(ctx context.Context, orderID string) (*entities.Order, error) {
 	if orderID == "" {
 		return nil, ErrInvalidOrderID
@@ -89,31 +418,329 @@ func (uc *OrderUseCase) GetOrder(ctx context.Context, orderID string) (*entities
 	return order, nil
 }
 
-func (uc *OrderUseCase) UpdateOrderStatus(ctx context.Context, orderID, status string) (*entities.Order, error) {
+// GetOrderHistory returns the status changes of the order, oldest first. Orders
+// created before the history was kept only have the changes made since.
+func (uc *OrderUseCase) GetOrderHistory(ctx context.Context, orderID string) ([]entities.StatusChange, error) {
 	if orderID == "" {
 		return nil, ErrInvalidOrderID
 	}
-	if !entities.ValidStatus(status) {
+
+	history, err := uc.orderRepo.GetHistory(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+
+	return history, nil
+}
+
+// UpdateOrderStatusInput describes a status change. ChangedBy and Reason are
+// optional and recorded in the order's status history. If ExpectedVersion is
+// non-nil the update only succeeds while the order is still at that version.
+type UpdateOrderStatusInput struct {
+	OrderID         string
+	Status          string
+	ChangedBy       string
+	Reason          string
+	ExpectedVersion *int64
+}
+
+// UpdateOrderStatus moves the order to input.Status.
+func (uc *OrderUseCase) UpdateOrderStatus(ctx context.Context, input UpdateOrderStatusInput) (*entities.Order, error) {
+	if input.OrderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if !entities.ValidStatus(input.Status) {
 		return nil, ErrInvalidStatus
 	}
+	if len(input.Reason) > MaxStatusReasonLength {
+		return nil, ErrInvalidStatusReason
+	}
 
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	order, err := uc.orderRepo.GetByID(ctx, input.OrderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order for update: %w", err)
 	}
 
-	if err := uc.orderRepo.UpdateStatus(ctx, orderID, status); err != nil {
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return nil, fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, *input.ExpectedVersion, order.Version)
+	}
+
+	if !entities.CanTransition(order.Status, input.Status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, order.Status, input.Status)
+	}
+	// Setting the status the order already has changes nothing, so that the
+	// version of the order keeps matching its status history.
+	if order.Status == input.Status {
+		return order, nil
+	}
+
+	currentVersion := order.Version
+	previousStatus := order.Status
+	change := entities.StatusChange{
+		From:      previousStatus,
+		To:        input.Status,
+		ChangedAt: time.Now(),
+		Actor:     input.ChangedBy,
+		Reason:    input.Reason,
+		Version:   currentVersion + 1,
+	}
+	order.Status = input.Status
+	order.UpdatedAt = change.ChangedAt
+	order.Version = currentVersion + 1
+
+	event := newOrderEvent(ctx, entities.OrderEventStatusChanged, order)
+	event.PreviousStatus = previousStatus
+
+	// The version read above guards against a concurrent update slipping in
+	// between GetByID and UpdateStatus.
+	if err := uc.orderRepo.UpdateStatus(ctx, input.OrderID, change, currentVersion, event); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrVersionConflict):
+			return nil, fmt.Errorf("%w: order was modified concurrently", ErrVersionConflict)
+		case errors.Is(err, repositories.ErrInvalidTransition):
+			return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, previousStatus, input.Status)
+		}
 		return nil, fmt.Errorf("failed to update order status: %w", err)
 	}
 
-	order.Status = status
+	uc.metrics.OrderStatusChanged(order, previousStatus)
+	uc.logger.InfoContext(ctx, "Order status changed",
+		"order_id", order.OrderID,
+		"old_status", previousStatus,
+		"new_status", order.Status,
+		"version", order.Version)
+
+	return order, nil
+}
+
+// CancelOrderInput describes a cancellation. Comment is required when Reason is
+// entities.CancelReasonOther. If ExpectedVersion is non-nil the cancellation only
+// succeeds while the order is still at that version.
+type CancelOrderInput struct {
+	OrderID         string
+	Reason          entities.CancelReason
+	Comment         string
+	CancelledBy     string
+	ExpectedVersion *int64
+}
+
+// CancelOrder cancels an order that has not been paid for yet, recording who
+// cancelled it and why, and announces it with an order.cancelled event.
+func (uc *OrderUseCase) CancelOrder(ctx context.Context, input CancelOrderInput) (*entities.Order, error) {
+	if input.OrderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if err := validateCancellation(input); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order for cancellation: %w", err)
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return nil, fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, *input.ExpectedVersion, order.Version)
+	}
+
+	if !entities.OrderStatus(order.Status).Cancellable() {
+		return nil, fmt.Errorf("%w: %s orders cannot be cancelled", ErrInvalidTransition, order.Status)
+	}
+
+	cancellation := entities.Cancellation{
+		CancelledAt: time.Now(),
+		Reason:      input.Reason,
+		Comment:     input.Comment,
+		CancelledBy: input.CancelledBy,
+	}
+
+	currentVersion := order.Version
+	previousStatus := order.Status
+	order.Status = string(entities.OrderStatusCancelled)
+	order.UpdatedAt = cancellation.CancelledAt
+	order.Version = currentVersion + 1
+	order.Cancellation = &cancellation
+
+	event := newOrderEvent(ctx, entities.OrderEventCancelled, order)
+	event.PreviousStatus = previousStatus
+
+	if err := uc.orderRepo.Cancel(ctx, order.OrderID, cancellation, currentVersion, event); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrVersionConflict):
+			return nil, fmt.Errorf("%w: order was modified concurrently", ErrVersionConflict)
+		case errors.Is(err, repositories.ErrInvalidTransition):
+			return nil, fmt.Errorf("%w: order can no longer be cancelled", ErrInvalidTransition)
+		}
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	uc.metrics.OrderStatusChanged(order, previousStatus)
+	uc.logger.InfoContext(ctx, "Order cancelled",
+		"order_id", order.OrderID,
+		"old_status", previousStatus,
+		"reason", cancellation.Reason,
+		"cancelled_by", cancellation.CancelledBy,
+		"version", order.Version)
+
 	return order, nil
 }
 
+func validateCancellation(input CancelOrderInput) error {
+	switch {
+	case !entities.ValidCancelReason(input.Reason):
+		return fmt.Errorf("%w: unknown reason %q", ErrInvalidCancellation, input.Reason)
+	case input.CancelledBy == "":
+		return fmt.Errorf("%w: cancelled_by is required", ErrInvalidCancellation)
+	case len(input.Comment) > MaxCancelCommentLength:
+		return fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidCancellation, MaxCancelCommentLength)
+	case input.Reason == entities.CancelReasonOther && input.Comment == "":
+		return fmt.Errorf("%w: a comment is required when the reason is %s", ErrInvalidCancellation, entities.CancelReasonOther)
+	}
+	return nil
+}
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+
+	// MaxRequestLines bounds the lines of a CreateOrder request before they
+	// are merged, whatever the configured ItemLimits.
+	MaxRequestLines = 1000
+
+	MaxIdempotencyKeyLength = 128
+	MaxPromoCodeLength      = 64
+	MaxCancelCommentLength  = 1000
+	MaxStatusReasonLength   = 1000
+	MaxAddressFieldLength   = 200
+)
+
+// ListOrdersFilter narrows the orders returned by ListOrders. Zero-valued fields are ignored.
+type ListOrdersFilter struct {
+	UserID      string
+	Statuses    []string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// ListOrders returns a page of orders matching filter, newest first, and the token
+// for the next page. The token is empty when there are no more orders.
+func (uc *OrderUseCase) ListOrders(ctx context.Context, filter ListOrdersFilter, pageSize int, pageToken string) ([]*entities.Order, string, error) {
+	for _, s := range filter.Statuses {
+		if !entities.ValidStatus(s) {
+			return nil, "", ErrInvalidStatus
+		}
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return nil, "", ErrInvalidTimeRange
+	}
+
+	switch {
+	case pageSize < 0:
+		return nil, "", ErrInvalidPageSize
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+
+	repoFilter := repositories.OrderFilter{
+		UserID:      filter.UserID,
+		Statuses:    filter.Statuses,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		// Fetch one extra order to find out whether another page exists.
+		Limit: pageSize + 1,
+	}
+
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		repoFilter.After = cursor
+	}
+
+	orders, err := uc.orderRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	if len(orders) <= pageSize {
+		return orders, "", nil
+	}
+
+	orders = orders[:pageSize]
+	last := orders[len(orders)-1]
+	nextPageToken, err := encodePageToken(&repositories.OrderCursor{CreatedAt: last.CreatedAt, OrderID: last.OrderID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return orders, nextPageToken, nil
+}
+
+type pageToken struct {
+	CreatedAt time.Time `json:"created_at"`
+	OrderID   string    `json:"order_id"`
+}
+
+func encodePageToken(cursor *repositories.OrderCursor) (string, error) {
+	data, err := json.Marshal(pageToken{CreatedAt: cursor.CreatedAt, OrderID: cursor.OrderID})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string) (*repositories.OrderCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var pt pageToken
+	if err := json.Unmarshal(data, &pt); err != nil || pt.OrderID == "" {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &repositories.OrderCursor{CreatedAt: pt.CreatedAt, OrderID: pt.OrderID}, nil
+}
+
 var (
 	ErrInvalidUserID  = errors.New("invalid user ID")
 	ErrInvalidOrderID = errors.New("invalid order ID")
 	ErrEmptyItems     = errors.New("items list cannot be empty")
 	ErrInvalidItem    = errors.New("invalid item")
 	ErrInvalidStatus  = errors.New("invalid order status")
+
+	ErrInvalidCurrency  = errors.New("invalid currency")
+	ErrCurrencyMismatch = errors.New("item currency does not match order currency")
+
+	ErrUnknownProduct     = errors.New("unknown product")
+	ErrProductUnavailable = errors.New("product is not available")
+	ErrPriceMismatch      = errors.New("item price does not match the catalog price")
+	ErrCatalogUnavailable = errors.New("product catalog is unavailable")
+
+	ErrUnknownPromoCode       = errors.New("unknown promo code")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to this order")
+
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	ErrVersionConflict   = errors.New("order version conflict")
+
+	ErrItemNotFound      = errors.New("item not found in order")
+	ErrItemsNotEditable  = errors.New("items can only be changed while the order is pending")
+	ErrItemLimitExceeded = errors.New("order exceeds the item limits")
+
+	ErrInvalidShippingAddress = errors.New("invalid shipping details")
+	ErrShippingNotEditable    = errors.New("shipping details can only be changed while the order is pending")
+
+	ErrInvalidCancellation = errors.New("invalid cancellation")
+	ErrInvalidStatusReason = errors.New("status change reason is too long")
+
+	ErrInvalidIdempotencyKey = errors.New("idempotency key is too long")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+
+	ErrInvalidPageSize  = errors.New("page size cannot be negative")
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidTimeRange = errors.New("created_from must be before created_to")
 )
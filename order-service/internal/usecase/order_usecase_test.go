@@ -2,160 +2,337 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"sync"
+	"strings"
 	"testing"
+	"time"
 
 	"order-service/internal/domain/entities"
 	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
-type MockOrderRepository struct {
+type MockOrderMetrics struct {
 	mock.Mock
 }
 
-func (m *MockOrderRepository) Create(ctx context.Context, order *entities.Order) error {
-	args := m.Called(ctx, order)
-	return args.Error(0)
+func (m *MockOrderMetrics) OrderCreated(order *entities.Order) {
+	m.Called(order)
 }
 
-func (m *MockOrderRepository) GetByID(ctx context.Context, orderID string) (*entities.Order, error) {
-	args := m.Called(ctx, orderID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entities.Order), args.Error(1)
-}
-
-func (m *MockOrderRepository) UpdateStatus(ctx context.Context, orderID, status string) error {
-	args := m.Called(ctx, orderID, status)
-	return args.Error(0)
-}
-
-type MockNatsPublisher struct {
-	mock.Mock
+func (m *MockOrderMetrics) OrderStatusChanged(order *entities.Order, previousStatus string) {
+	m.Called(order, previousStatus)
 }
 
-func (m *MockNatsPublisher) PublishOrderCreated(ctx context.Context, order *entities.Order) error {
-	args := m.Called(ctx, order)
-	return args.Error(0)
-}
-
-func (m *MockNatsPublisher) Close() {
-	m.Called()
+func rub(kopecks int64) entities.Money {
+	return entities.NewMoney(kopecks, "RUB")
 }
 
 func TestOrderUseCase_CreateOrder(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	items := []entities.Item{
-		{ProductID: "prod1", Quantity: 2, Price: 10.0},
-		{ProductID: "prod2", Quantity: 1, Price: 5.0},
+		{ProductID: "prod1", Quantity: 2, Price: rub(1000)},
+		{ProductID: "prod2", Quantity: 1, Price: rub(500)},
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order")).
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
 		Return(nil).
 		Run(func(args mock.Arguments) {
 			order := args.Get(1).(*entities.Order)
 			assert.Equal(t, "PENDING", order.Status)
-			assert.Equal(t, 25.0, order.TotalAmount)
+			assert.Equal(t, rub(2500), order.TotalAmount)
 			assert.Equal(t, "user123", order.UserID)
 			assert.Len(t, order.Items, 2)
-		})
 
-	mockNats.On("PublishOrderCreated", mock.Anything, mock.AnythingOfType("*entities.Order")).
-		Return(nil).
-		Run(func(args mock.Arguments) {
-			wg.Done()
+			event := args.Get(2).(*entities.OrderEvent)
+			assert.NotEmpty(t, event.EventID)
+			assert.Equal(t, entities.OrderEventCreated, event.Type)
+			assert.Equal(t, order, event.Order)
 		})
 
-	order, err := useCase.CreateOrder(ctx, "user123", items)
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, order)
 	assert.Equal(t, "user123", order.UserID)
 	assert.Equal(t, "PENDING", order.Status)
-	assert.Equal(t, 25.0, order.TotalAmount)
+	assert.Equal(t, rub(2500), order.TotalAmount)
 	assert.Len(t, order.Items, 2)
 
-	wg.Wait()
-
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertExpectations(t)
 }
 
-func TestOrderUseCase_CreateOrder_NATSErrorNotFatal(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+func TestOrderUseCase_CreateOrder_RepositoryError(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	items := []entities.Item{
-		{ProductID: "prod1", Quantity: 1, Price: 10.0},
+		{ProductID: "prod1", Quantity: 1, Price: rub(1000)},
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(errors.New("transaction aborted"))
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items})
+
+	assert.Error(t, err)
+	assert.Nil(t, order)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_ExactTotal(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	// 0.1 + 0.2 in float64 is 0.30000000000000004.
+	items := []entities.Item{
+		{ProductID: "prod1", Quantity: 1, Price: rub(10)},
+		{ProductID: "prod2", Quantity: 1, Price: rub(20)},
+		{ProductID: "prod3", Quantity: 3, Price: rub(3333)},
+	}
 
-	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order")).
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
 		Return(nil)
 
-	mockNats.On("PublishOrderCreated", mock.Anything, mock.AnythingOfType("*entities.Order")).
-		Return(errors.New("nats connection failed")).
-		Run(func(args mock.Arguments) {
-			wg.Done()
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rub(10029), order.TotalAmount)
+}
+
+func TestOrderUseCase_CreateOrder_Currency(t *testing.T) {
+	eur := func(cents int64) entities.Money { return entities.NewMoney(cents, "EUR") }
+
+	tests := []struct {
+		name     string
+		currency string
+		items    []entities.Item
+		wantErr  error
+	}{
+		{
+			name:     "supported currency",
+			currency: "EUR",
+			items:    []entities.Item{{ProductID: "prod1", Quantity: 2, Price: eur(150)}},
+		},
+		{
+			name:  "defaults to RUB",
+			items: []entities.Item{{ProductID: "prod1", Quantity: 2, Price: rub(150)}},
+		},
+		{
+			name:     "unsupported currency",
+			currency: "GBP",
+			items:    []entities.Item{{ProductID: "prod1", Quantity: 1, Price: entities.NewMoney(100, "GBP")}},
+			wantErr:  ErrInvalidCurrency,
+		},
+		{
+			name:     "malformed currency",
+			currency: "euro",
+			items:    []entities.Item{{ProductID: "prod1", Quantity: 1, Price: entities.NewMoney(100, "euro")}},
+			wantErr:  ErrInvalidCurrency,
+		},
+		{
+			name:     "item in another currency",
+			currency: "EUR",
+			items: []entities.Item{
+				{ProductID: "prod1", Quantity: 1, Price: eur(100)},
+				{ProductID: "prod2", Quantity: 1, Price: rub(100)},
+			},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			useCase := NewOrderUseCase(mockRepo, WithSupportedCurrencies("RUB", "EUR", "USD"))
+
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+				Return(nil)
+
+			order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+				UserID:   "user123",
+				Currency: tt.currency,
+				Items:    tt.items,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, order)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.items[0].Price.Currency, order.Currency)
+			assert.Equal(t, order.Currency, order.TotalAmount.Currency)
 		})
+	}
+}
+
+func TestOrderUseCase_CreateOrder_IdempotencyKey(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	items := []entities.Item{
+		{ProductID: "prod1", Quantity: 1, Price: rub(1000)},
+	}
 
-	order, err := useCase.CreateOrder(ctx, "user123", items)
+	var created *entities.Order
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").
+		Return((*entities.Order)(nil), repositories.ErrOrderNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entities.Order)
+			assert.Equal(t, "key-1", created.IdempotencyKey)
+			assert.NotEmpty(t, created.RequestHash)
+		}).Once()
 
+	first, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items, IdempotencyKey: "key-1"})
 	assert.NoError(t, err)
-	assert.NotNil(t, order)
-	assert.Equal(t, "user123", order.UserID)
-	assert.Equal(t, "PENDING", order.Status)
 
-	wg.Wait()
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(created, nil)
+
+	replayed, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items, IdempotencyKey: "key-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, first.OrderID, replayed.OrderID)
+
+	changed := []entities.Item{
+		{ProductID: "prod1", Quantity: 2, Price: rub(1000)},
+	}
+	conflict, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: changed, IdempotencyKey: "key-1"})
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	assert.Nil(t, conflict)
 
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
 }
 
-func TestOrderUseCase_CreateOrder_WithoutNATSPublisher(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
+func TestOrderUseCase_CreateOrder_IdempotencyKeyRace(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, nil)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	items := []entities.Item{
-		{ProductID: "prod1", Quantity: 1, Price: 10.0},
+		{ProductID: "prod1", Quantity: 1, Price: rub(1000)},
 	}
+	requestHash, err := hashCreateRequest(CreateOrderInput{UserID: "user123", Currency: "RUB", Items: items, IdempotencyKey: "key-1"})
+	assert.NoError(t, err)
 
-	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order")).
-		Return(nil)
+	winner := &entities.Order{OrderID: "winner", UserID: "user123", IdempotencyKey: "key-1", RequestHash: requestHash}
+
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").
+		Return((*entities.Order)(nil), repositories.ErrOrderNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(repositories.ErrOrderAlreadyExists)
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(winner, nil).Once()
 
-	order, err := useCase.CreateOrder(ctx, "user123", items)
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: "user123", Items: items, IdempotencyKey: "key-1"})
 
 	assert.NoError(t, err)
-	assert.NotNil(t, order)
+	assert.Equal(t, "winner", order.OrderID)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_IdempotencyKeyMatchesNormalizedRequest(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "WELCOME10").Return(&entities.Promotion{
+		Code:  "WELCOME10",
+		Rules: []entities.DiscountRule{entities.PercentageOff{Percent: 10}},
+	}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions))
+	ctx := context.Background()
+	items := []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}}
+
+	address := berlinAddress()
+	address.City = " Berlin "
+	address.CountryCode = "de"
+
+	var created *entities.Order
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").
+		Return((*entities.Order)(nil), repositories.ErrOrderNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entities.Order) }).Once()
+
+	first, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID:          "user123",
+		Items:           items,
+		IdempotencyKey:  "key-1",
+		PromoCode:       " welcome10 ",
+		Region:          "de-by",
+		ShippingAddress: address,
+		DeliveryMethod:  "courier",
+	})
+	assert.NoError(t, err)
+
+	mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(created, nil)
+
+	replayed, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID:          "user123",
+		Items:           items,
+		IdempotencyKey:  "key-1",
+		PromoCode:       "WELCOME10",
+		Region:          "DE-BY",
+		ShippingAddress: berlinAddress(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, first.OrderID, replayed.OrderID)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestHashCreateRequest_DefaultCurrencyKeepsLegacyHash(t *testing.T) {
+	items := []entities.Item{
+		{ProductID: "prod1", Quantity: 1, Price: rub(1000)},
+	}
+	// Requests made before currencies existed were hashed from these alone.
+	legacy, err := json.Marshal(struct {
+		UserID string          `json:"user_id"`
+		Items  []entities.Item `json:"items"`
+	}{"user123", items})
+	assert.NoError(t, err)
+	sum := sha256.Sum256(legacy)
+
+	for _, currency := range []string{"", entities.DefaultCurrency} {
+		hash, err := hashCreateRequest(CreateOrderInput{UserID: "user123", Currency: currency, Items: items})
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(sum[:]), hash, "currency %q", currency)
+	}
+
+	hash, err := hashCreateRequest(CreateOrderInput{UserID: "user123", Currency: "EUR", Items: items})
+	assert.NoError(t, err)
+	assert.NotEqual(t, hex.EncodeToString(sum[:]), hash)
+}
+
 func TestOrderUseCase_CreateOrder_InvalidInput(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -167,48 +344,57 @@ func TestOrderUseCase_CreateOrder_InvalidInput(t *testing.T) {
 		{
 			name:    "empty user id",
 			userID:  "",
-			items:   []entities.Item{{ProductID: "prod1", Quantity: 1, Price: 10.0}},
-			wantErr: "invalid user ID",
+			items:   []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+			wantErr: "invalid user ID: user_id: is required",
 		},
 		{
 			name:    "empty items",
 			userID:  "user123",
 			items:   []entities.Item{},
-			wantErr: "items list cannot be empty",
+			wantErr: "items list cannot be empty: items: must not be empty",
 		},
 		{
 			name:    "invalid quantity",
 			userID:  "user123",
-			items:   []entities.Item{{ProductID: "prod1", Quantity: 0, Price: 10.0}},
-			wantErr: "invalid item: item 0 has invalid quantity",
+			items:   []entities.Item{{ProductID: "prod1", Quantity: 0, Price: rub(1000)}},
+			wantErr: "invalid item: items[0].quantity: must be positive",
 		},
 		{
 			name:    "invalid price",
 			userID:  "user123",
-			items:   []entities.Item{{ProductID: "prod1", Quantity: 1, Price: -10.0}},
-			wantErr: "invalid item: item 0 has invalid price",
+			items:   []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(-1000)}},
+			wantErr: "invalid item: items[0].unit_price: must not be negative",
+		},
+		{
+			name:   "mixed currencies",
+			userID: "user123",
+			items: []entities.Item{
+				{ProductID: "prod1", Quantity: 1, Price: rub(1000)},
+				{ProductID: "prod2", Quantity: 1, Price: entities.NewMoney(1000, "USD")},
+			},
+			wantErr: "item currency does not match order currency: items[1].unit_price: is priced in USD, order is in RUB",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			order, err := useCase.CreateOrder(ctx, tt.userID, tt.items)
+			order, err := useCase.CreateOrder(ctx, CreateOrderInput{UserID: tt.userID, Items: tt.items})
 			assert.Error(t, err)
 			assert.Nil(t, order)
 			assert.Contains(t, err.Error(), tt.wantErr)
+			var validationErr *ValidationError
+			assert.True(t, errors.As(err, &validationErr))
 
-			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
 
-			mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
 		})
 	}
 }
 
 func TestOrderUseCase_GetOrder(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	expectedOrder := &entities.Order{
@@ -225,14 +411,12 @@ func TestOrderUseCase_GetOrder(t *testing.T) {
 	assert.Equal(t, expectedOrder, order)
 
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
 }
 
 func TestOrderUseCase_GetOrder_NotFound(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	mockRepo.On("GetByID", mock.Anything, "non-existent").Return((*entities.Order)(nil), repositories.ErrOrderNotFound)
@@ -244,14 +428,12 @@ func TestOrderUseCase_GetOrder_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "order not found")
 
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
 }
 
 func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	existingOrder := &entities.Order{
@@ -261,72 +443,633 @@ func TestOrderUseCase_UpdateOrderStatus(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
-	mockRepo.On("UpdateStatus", mock.Anything, "test-order", "PAID").Return(nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", repositorytest.ChangeTo("PAID"), int64(0), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			event := args.Get(4).(*entities.OrderEvent)
+			assert.Equal(t, entities.OrderEventStatusChanged, event.Type)
+			assert.Equal(t, "PENDING", event.PreviousStatus)
+			assert.Equal(t, "PAID", event.Order.Status)
+			assert.Equal(t, "test-order", event.Order.OrderID)
+		})
 
-	order, err := useCase.UpdateOrderStatus(ctx, "test-order", "PAID")
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "PAID"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "PAID", order.Status)
 
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_RecordsHistory(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	createdAt := time.Now().Add(-time.Hour)
+	existingOrder := &entities.Order{
+		OrderID:   "test-order",
+		UserID:    "user123",
+		Status:    "PAID",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Version:   2,
+	}
+
+	var change entities.StatusChange
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", repositorytest.ChangeTo("REFUNDED"), int64(2), mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			change = args.Get(2).(entities.StatusChange)
+		})
+
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{
+		OrderID:   "test-order",
+		Status:    "REFUNDED",
+		ChangedBy: "support:alice",
+		Reason:    "item arrived damaged",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "PAID", change.From)
+	assert.Equal(t, "REFUNDED", change.To)
+	assert.Equal(t, "support:alice", change.Actor)
+	assert.Equal(t, "item arrived damaged", change.Reason)
+	assert.Equal(t, int64(3), change.Version)
+	assert.True(t, change.ChangedAt.After(createdAt))
+	assert.Equal(t, change.ChangedAt, order.UpdatedAt)
+
+	_, err = useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{
+		OrderID: "test-order",
+		Status:  "REFUNDED",
+		Reason:  strings.Repeat("x", MaxStatusReasonLength+1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidStatusReason)
+
+	mockRepo.AssertNumberOfCalls(t, "UpdateStatus", 1)
+}
+
+func TestOrderUseCase_GetOrderHistory(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	history := []entities.StatusChange{
+		{To: "PENDING", Actor: "user123", Version: 1},
+		{From: "PENDING", To: "PAID", Version: 2},
+	}
+	mockRepo.On("GetHistory", mock.Anything, "test-order").Return(history, nil)
+	mockRepo.On("GetHistory", mock.Anything, "missing").Return(nil, repositories.ErrOrderNotFound)
+
+	got, err := useCase.GetOrderHistory(ctx, "test-order")
+	assert.NoError(t, err)
+	assert.Equal(t, history, got)
+
+	_, err = useCase.GetOrderHistory(ctx, "missing")
+	assert.ErrorIs(t, err, repositories.ErrOrderNotFound)
+
+	_, err = useCase.GetOrderHistory(ctx, "")
+	assert.ErrorIs(t, err, ErrInvalidOrderID)
+
+	mockRepo.AssertExpectations(t)
 }
 
 func TestOrderUseCase_UpdateOrderStatus_InvalidStatus(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
-	_, err := useCase.UpdateOrderStatus(ctx, "test-order", "INVALID_STATUS")
+	_, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "INVALID_STATUS"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid order status")
 
 	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything)
 	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
 }
 
 func TestOrderUseCase_UpdateOrderStatus_NotFound(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	mockRepo.On("GetByID", mock.Anything, "non-existent").Return((*entities.Order)(nil), repositories.ErrOrderNotFound)
 
-	_, err := useCase.UpdateOrderStatus(ctx, "non-existent", "PAID")
+	_, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "non-existent", Status: "PAID"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "order not found")
 
 	mockRepo.AssertExpectations(t)
 	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
 }
 
 func TestOrderUseCase_UpdateOrderStatus_AlreadyInStatus(t *testing.T) {
-	mockRepo := new(MockOrderRepository)
-	mockNats := new(MockNatsPublisher)
+	mockRepo := new(repositorytest.MockOrderRepository)
 
-	useCase := NewOrderUseCase(mockRepo, mockNats)
+	useCase := NewOrderUseCase(mockRepo)
 	ctx := context.Background()
 
 	existingOrder := &entities.Order{
 		OrderID: "test-order",
 		UserID:  "user123",
 		Status:  "PAID",
+		Version: 3,
+	}
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "PAID"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PAID", order.Status)
+	// Nothing is stored, so the version stays in step with the history.
+	assert.Equal(t, int64(3), order.Version)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_InvalidTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{name: "cancelled to pending", from: "CANCELLED", to: "PENDING"},
+		{name: "paid to failed", from: "PAID", to: "FAILED"},
+		{name: "pending to refunded", from: "PENDING", to: "REFUNDED"},
+		{name: "refunded to paid", from: "REFUNDED", to: "PAID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+
+			useCase := NewOrderUseCase(mockRepo)
+			ctx := context.Background()
+
+			existingOrder := &entities.Order{
+				OrderID: "test-order",
+				UserID:  "user123",
+				Status:  tt.from,
+			}
+
+			mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+
+			order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: tt.to})
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			assert.Nil(t, order)
+
+			mockRepo.AssertExpectations(t)
+			mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_UpdateOrderStatus_ConcurrentTransition(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	existingOrder := &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+	}
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", repositorytest.ChangeTo("PAID"), int64(0), mock.Anything).Return(repositories.ErrInvalidTransition)
+
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "PAID"})
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+	assert.Nil(t, order)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_ExpectedVersion(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	existingOrder := &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+		Version: 3,
 	}
 
 	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
-	mockRepo.On("UpdateStatus", mock.Anything, "test-order", "PAID").Return(nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", repositorytest.ChangeTo("PAID"), int64(3), mock.Anything).Return(nil)
 
-	order, err := useCase.UpdateOrderStatus(ctx, "test-order", "PAID")
+	expectedVersion := int64(3)
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "PAID", ExpectedVersion: &expectedVersion})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "PAID", order.Status)
+	assert.Equal(t, int64(4), order.Version)
 
 	mockRepo.AssertExpectations(t)
-	mockNats.AssertNotCalled(t, "PublishOrderCreated", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_StaleExpectedVersion(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	existingOrder := &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+		Version: 4,
+	}
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+
+	expectedVersion := int64(3)
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "PAID", ExpectedVersion: &expectedVersion})
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_Cancelled(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+		Version: 1,
+	}, nil)
+	// Without CancelOrder there is no reason or actor to record, and the
+	// cancellation is announced as a plain status change.
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", mock.MatchedBy(func(change entities.StatusChange) bool {
+		return change.To == "CANCELLED" && change.Reason == "" && change.Actor == ""
+	}), int64(1), mock.MatchedBy(func(e *entities.OrderEvent) bool {
+		return e.Type == entities.OrderEventStatusChanged && e.PreviousStatus == "PENDING" && e.Order.Cancellation == nil
+	})).Return(nil)
+
+	order, err := useCase.UpdateOrderStatus(context.Background(), UpdateOrderStatusInput{OrderID: "test-order", Status: "CANCELLED"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CANCELLED", order.Status)
+	assert.Nil(t, order.Cancellation)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_UpdateOrderStatus_ConcurrentUpdate(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	existingOrder := &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+		Version: 1,
+	}
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "test-order", repositorytest.ChangeTo("CANCELLED"), int64(1), mock.Anything).Return(repositories.ErrVersionConflict)
+
+	order, err := useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "test-order", Status: "CANCELLED"})
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_Metrics(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	mockMetrics := new(MockOrderMetrics)
+
+	useCase := NewOrderUseCase(mockRepo, WithMetrics(mockMetrics))
+	ctx := context.Background()
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+	mockMetrics.On("OrderCreated", mock.AnythingOfType("*entities.Order")).Once()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+	})
+	assert.NoError(t, err)
+
+	mockRepo.On("GetByID", mock.Anything, order.OrderID).Return(order, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, order.OrderID, repositorytest.ChangeTo("PAID"), int64(1), mock.AnythingOfType("*entities.OrderEvent")).Return(nil)
+	mockMetrics.On("OrderStatusChanged", order, "PENDING").Once()
+
+	_, err = useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: order.OrderID, Status: "PAID"})
+	assert.NoError(t, err)
+
+	// A failed write is not reported.
+	mockRepo.On("GetByID", mock.Anything, "other-order").Return(&entities.Order{OrderID: "other-order", Status: "PENDING", Version: 1}, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, "other-order", repositorytest.ChangeTo("PAID"), int64(1), mock.Anything).Return(repositories.ErrVersionConflict)
+
+	_, err = useCase.UpdateOrderStatus(ctx, UpdateOrderStatusInput{OrderID: "other-order", Status: "PAID"})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	mockMetrics.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CancelOrder(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	existingOrder := &entities.Order{
+		OrderID: "test-order",
+		UserID:  "user123",
+		Status:  "PENDING",
+		Version: 2,
+	}
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(existingOrder, nil)
+	mockRepo.On("Cancel", mock.Anything, "test-order", mock.MatchedBy(func(c entities.Cancellation) bool {
+		return c.Reason == entities.CancelReasonOutOfStock && c.CancelledBy == "warehouse" && !c.CancelledAt.IsZero()
+	}), int64(2), mock.MatchedBy(func(e *entities.OrderEvent) bool {
+		return e.Type == entities.OrderEventCancelled && e.PreviousStatus == "PENDING" &&
+			e.Order.Status == "CANCELLED" && e.Order.Cancellation != nil
+	})).Return(nil)
+
+	order, err := useCase.CancelOrder(ctx, CancelOrderInput{
+		OrderID:     "test-order",
+		Reason:      entities.CancelReasonOutOfStock,
+		Comment:     "last unit was damaged",
+		CancelledBy: "warehouse",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CANCELLED", order.Status)
+	assert.Equal(t, int64(3), order.Version)
+	assert.Equal(t, entities.CancelReasonOutOfStock, order.Cancellation.Reason)
+	assert.Equal(t, "last unit was damaged", order.Cancellation.Comment)
+	assert.Equal(t, "warehouse", order.Cancellation.CancelledBy)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CancelOrder_InvalidInput(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		input   CancelOrderInput
+		wantErr error
+	}{
+		{
+			name:    "empty order id",
+			input:   CancelOrderInput{Reason: entities.CancelReasonCustomerRequest, CancelledBy: "user123"},
+			wantErr: ErrInvalidOrderID,
+		},
+		{
+			name:    "unknown reason",
+			input:   CancelOrderInput{OrderID: "test-order", Reason: "BORED", CancelledBy: "user123"},
+			wantErr: ErrInvalidCancellation,
+		},
+		{
+			name:    "missing reason",
+			input:   CancelOrderInput{OrderID: "test-order", CancelledBy: "user123"},
+			wantErr: ErrInvalidCancellation,
+		},
+		{
+			name:    "missing actor",
+			input:   CancelOrderInput{OrderID: "test-order", Reason: entities.CancelReasonCustomerRequest},
+			wantErr: ErrInvalidCancellation,
+		},
+		{
+			name:    "other without comment",
+			input:   CancelOrderInput{OrderID: "test-order", Reason: entities.CancelReasonOther, CancelledBy: "support"},
+			wantErr: ErrInvalidCancellation,
+		},
+		{
+			name: "comment too long",
+			input: CancelOrderInput{
+				OrderID:     "test-order",
+				Reason:      entities.CancelReasonOther,
+				Comment:     strings.Repeat("x", MaxCancelCommentLength+1),
+				CancelledBy: "support",
+			},
+			wantErr: ErrInvalidCancellation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := useCase.CancelOrder(ctx, tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, order)
+		})
+	}
+
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_CancelOrder_NotCancellable(t *testing.T) {
+	for _, status := range []string{"PAID", "FAILED", "CANCELLED", "REFUNDED"} {
+		t.Run(status, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+
+			useCase := NewOrderUseCase(mockRepo)
+			ctx := context.Background()
+
+			mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{OrderID: "test-order", Status: status, Version: 1}, nil)
+
+			order, err := useCase.CancelOrder(ctx, CancelOrderInput{
+				OrderID:     "test-order",
+				Reason:      entities.CancelReasonCustomerRequest,
+				CancelledBy: "user123",
+			})
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			assert.Nil(t, order)
+
+			mockRepo.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_CancelOrder_VersionConflict(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{OrderID: "test-order", Status: "PENDING", Version: 3}, nil)
+
+	stale := int64(2)
+	order, err := useCase.CancelOrder(ctx, CancelOrderInput{
+		OrderID:         "test-order",
+		Reason:          entities.CancelReasonCustomerRequest,
+		CancelledBy:     "user123",
+		ExpectedVersion: &stale,
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+	mockRepo.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The order changes between the read and the write.
+	mockRepo.On("Cancel", mock.Anything, "test-order", mock.Anything, int64(3), mock.Anything).Return(repositories.ErrVersionConflict)
+
+	order, err = useCase.CancelOrder(ctx, CancelOrderInput{
+		OrderID:     "test-order",
+		Reason:      entities.CancelReasonCustomerRequest,
+		CancelledBy: "user123",
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+}
+
+func TestNewOrderEvent_CapturesTraceContext(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	order := &entities.Order{OrderID: "order-1"}
+
+	event := newOrderEvent(context.Background(), entities.OrderEventCreated, order)
+	assert.Nil(t, event.TraceContext)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	event = newOrderEvent(ctx, entities.OrderEventCreated, order)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", event.TraceContext["traceparent"])
+}
+
+func TestOrderUseCase_ListOrders_Pagination(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	orders := []*entities.Order{
+		{OrderID: "order-3", UserID: "user123", CreatedAt: now},
+		{OrderID: "order-2", UserID: "user123", CreatedAt: now.Add(-time.Minute)},
+		{OrderID: "order-1", UserID: "user123", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repositories.OrderFilter) bool {
+		return f.After == nil
+	})).Return(orders, nil).Once()
+
+	page, nextPageToken, err := useCase.ListOrders(ctx, ListOrdersFilter{UserID: "user123"}, 2, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, "order-2", page[1].OrderID)
+	assert.NotEmpty(t, nextPageToken)
+
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repositories.OrderFilter) bool {
+		return f.After != nil && f.After.OrderID == "order-2" && f.After.CreatedAt.Equal(orders[1].CreatedAt) &&
+			f.UserID == "user123" && f.Limit == 3
+	})).Return(orders[2:], nil).Once()
+
+	page, nextPageToken, err = useCase.ListOrders(ctx, ListOrdersFilter{UserID: "user123"}, 2, nextPageToken)
+
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "order-1", page[0].OrderID)
+	assert.Empty(t, nextPageToken)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_ListOrders_PageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageSize  int
+		wantLimit int
+	}{
+		{name: "default", pageSize: 0, wantLimit: DefaultPageSize + 1},
+		{name: "clamped to max", pageSize: MaxPageSize + 50, wantLimit: MaxPageSize + 1},
+		{name: "as requested", pageSize: 10, wantLimit: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			useCase := NewOrderUseCase(mockRepo)
+
+			mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f repositories.OrderFilter) bool {
+				return f.Limit == tt.wantLimit
+			})).Return([]*entities.Order{}, nil)
+
+			_, _, err := useCase.ListOrders(context.Background(), ListOrdersFilter{}, tt.pageSize, "")
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderUseCase_ListOrders_InvalidInput(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		filter    ListOrdersFilter
+		pageSize  int
+		pageToken string
+		wantErr   error
+	}{
+		{
+			name:    "unknown status",
+			filter:  ListOrdersFilter{Statuses: []string{"PENDING", "SHIPPED"}},
+			wantErr: ErrInvalidStatus,
+		},
+		{
+			name:    "inverted time range",
+			filter:  ListOrdersFilter{CreatedFrom: now, CreatedTo: now.Add(-time.Hour)},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name:     "negative page size",
+			pageSize: -1,
+			wantErr:  ErrInvalidPageSize,
+		},
+		{
+			name:      "malformed page token",
+			pageToken: "not a token",
+			wantErr:   ErrInvalidPageToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			useCase := NewOrderUseCase(mockRepo)
+
+			orders, _, err := useCase.ListOrders(context.Background(), tt.filter, tt.pageSize, tt.pageToken)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, orders)
+			mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
 }
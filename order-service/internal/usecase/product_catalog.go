@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"order-service/internal/domain/entities"
+)
+
+// ProductCatalog resolves product IDs to their current price and availability.
+type ProductCatalog interface {
+	// GetProducts returns the known products among productIDs, keyed by ID.
+	// Unknown products are absent from the map rather than reported as errors.
+	GetProducts(ctx context.Context, productIDs []string) (map[string]entities.Product, error)
+}
+
+// PricePolicy decides what CreateOrder does with the prices sent by the client
+// when a ProductCatalog is configured.
+type PricePolicy string
+
+const (
+	// PricePolicyOverride replaces client prices with catalog prices; clients
+	// may leave prices out altogether.
+	PricePolicyOverride PricePolicy = "override"
+	// PricePolicyVerify rejects orders whose prices differ from the catalog.
+	PricePolicyVerify PricePolicy = "verify"
+)
+
+// WithProductCatalog makes CreateOrder reject products unknown to catalog or
+// currently unavailable and price items according to policy. The catalog's
+// product categories also replace those sent by the client. Client prices are
+// trusted as they are by default.
+func WithProductCatalog(catalog ProductCatalog, policy PricePolicy) Option {
+	return func(uc *OrderUseCase) {
+		uc.catalog = catalog
+		uc.pricePolicy = policy
+	}
+}
+
+// priceItems checks items against the catalog and returns them priced according
+// to the price policy. items itself is left untouched.
+func (uc *OrderUseCase) priceItems(ctx context.Context, items []entities.Item) ([]entities.Item, error) {
+	if uc.catalog == nil {
+		return items, nil
+	}
+
+	ids := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		if item.ProductID == "" {
+			return nil, fmt.Errorf("%w: item %d has no product ID", ErrInvalidItem, i)
+		}
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+
+	products, err := uc.catalog.GetProducts(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCatalogUnavailable, err)
+	}
+
+	priced := make([]entities.Item, len(items))
+	for i, item := range items {
+		product, ok := products[item.ProductID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, item.ProductID)
+		case !product.Available:
+			return nil, fmt.Errorf("%w: %s", ErrProductUnavailable, item.ProductID)
+		case uc.pricePolicy == PricePolicyVerify && item.Price != product.Price:
+			return nil, fmt.Errorf("%w: %s costs %s, not %s", ErrPriceMismatch, item.ProductID, product.Price, item.Price)
+		}
+
+		item.Price = product.Price
+		if product.Category != "" {
+			item.Category = product.Category
+		}
+		priced[i] = item
+	}
+	return priced, nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductCatalog struct {
+	mock.Mock
+}
+
+func (m *MockProductCatalog) GetProducts(ctx context.Context, productIDs []string) (map[string]entities.Product, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]entities.Product), args.Error(1)
+}
+
+func testCatalog() *MockProductCatalog {
+	catalog := new(MockProductCatalog)
+	catalog.On("GetProducts", mock.Anything, mock.Anything).Return(map[string]entities.Product{
+		"prod1":    {ProductID: "prod1", Price: rub(1000), Available: true, Category: "books"},
+		"prod2":    {ProductID: "prod2", Price: rub(500), Available: true},
+		"sold-out": {ProductID: "sold-out", Price: rub(700), Available: false},
+		"euro":     {ProductID: "euro", Price: entities.NewMoney(900, "EUR"), Available: true},
+	}, nil)
+	return catalog
+}
+
+func TestOrderUseCase_CreateOrder_PricePolicyOverride(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	catalog := testCatalog()
+
+	useCase := NewOrderUseCase(mockRepo, WithProductCatalog(catalog, PricePolicyOverride))
+
+	items := []entities.Item{
+		{ProductID: "prod1", Quantity: 2, Price: rub(1)},
+		{ProductID: "prod2", Quantity: 1, Category: "toys"},
+		{ProductID: "prod1", Quantity: 1, Price: rub(1), Category: "toys"},
+	}
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{UserID: "user123", Items: items})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rub(3500), order.TotalAmount)
+	assert.Equal(t, rub(1000), order.Items[0].Price)
+	assert.Equal(t, rub(500), order.Items[1].Price)
+	// Categories come from the catalog where it has one, so the two prod1
+	// lines agree once priced and are merged.
+	assert.Len(t, order.Items, 2)
+	assert.Equal(t, 3, order.Items[0].Quantity)
+	assert.Equal(t, "books", order.Items[0].Category)
+	assert.Equal(t, "toys", order.Items[1].Category)
+	// The caller's items are left as they were.
+	assert.Equal(t, rub(1), items[0].Price)
+	catalog.AssertCalled(t, "GetProducts", mock.Anything, []string{"prod1", "prod2"})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_PricePolicyVerify(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo, WithProductCatalog(testCatalog(), PricePolicyVerify))
+	ctx := context.Background()
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).Once()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 2, Price: rub(1000)}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, rub(2000), order.TotalAmount)
+
+	order, err = useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 2, Price: rub(1)}},
+	})
+	assert.ErrorIs(t, err, ErrPriceMismatch)
+	assert.Nil(t, order)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_CatalogRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    entities.Item
+		wantErr error
+	}{
+		{
+			name:    "unknown product",
+			item:    entities.Item{ProductID: "missing", Quantity: 1, Price: rub(1000)},
+			wantErr: ErrUnknownProduct,
+		},
+		{
+			name:    "unavailable product",
+			item:    entities.Item{ProductID: "sold-out", Quantity: 1, Price: rub(700)},
+			wantErr: ErrProductUnavailable,
+		},
+		{
+			name:    "no product ID",
+			item:    entities.Item{Quantity: 1, Price: rub(1000)},
+			wantErr: ErrInvalidItem,
+		},
+		{
+			name:    "catalog price in another currency",
+			item:    entities.Item{ProductID: "euro", Quantity: 1},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			useCase := NewOrderUseCase(mockRepo, WithProductCatalog(testCatalog(), PricePolicyOverride))
+
+			order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{UserID: "user123", Items: []entities.Item{tt.item}})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, order)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_CreateOrder_CatalogError(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	catalog := new(MockProductCatalog)
+	catalog.On("GetProducts", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	useCase := NewOrderUseCase(mockRepo, WithProductCatalog(catalog, PricePolicyOverride))
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, ErrCatalogUnavailable)
+	assert.Nil(t, order)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_CreateOrder_IdempotencyKeyAfterCatalogChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		products map[string]entities.Product
+		err      error
+	}{
+		{
+			name:     "price changed",
+			products: map[string]entities.Product{"prod1": {ProductID: "prod1", Price: rub(1200), Available: true}},
+		},
+		{
+			name:     "product unavailable",
+			products: map[string]entities.Product{"prod1": {ProductID: "prod1", Price: rub(1000), Available: false}},
+		},
+		{
+			name: "catalog down",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			catalog := new(MockProductCatalog)
+			catalog.On("GetProducts", mock.Anything, mock.Anything).Return(map[string]entities.Product{
+				"prod1": {ProductID: "prod1", Price: rub(1000), Available: true},
+			}, nil).Once()
+			catalog.On("GetProducts", mock.Anything, mock.Anything).Return(tt.products, tt.err)
+
+			useCase := NewOrderUseCase(mockRepo, WithProductCatalog(catalog, PricePolicyOverride))
+			ctx := context.Background()
+			input := CreateOrderInput{
+				UserID:         "user123",
+				Items:          []entities.Item{{ProductID: "prod1", Quantity: 1}},
+				IdempotencyKey: "key-1",
+			}
+
+			var created *entities.Order
+			mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").
+				Return((*entities.Order)(nil), repositories.ErrOrderNotFound).Once()
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+				Return(nil).
+				Run(func(args mock.Arguments) { created = args.Get(1).(*entities.Order) }).Once()
+
+			first, err := useCase.CreateOrder(ctx, input)
+			assert.NoError(t, err)
+			assert.Equal(t, rub(1000), first.TotalAmount)
+
+			mockRepo.On("GetByIdempotencyKey", mock.Anything, "user123", "key-1").Return(created, nil)
+
+			replayed, err := useCase.CreateOrder(ctx, input)
+			assert.NoError(t, err)
+			assert.Equal(t, first.OrderID, replayed.OrderID)
+			assert.Equal(t, rub(1000), replayed.TotalAmount)
+
+			// The retry is answered without asking the catalog.
+			catalog.AssertNumberOfCalls(t, "GetProducts", 1)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
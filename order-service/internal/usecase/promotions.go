@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"order-service/internal/domain/entities"
+)
+
+// PromotionCatalog looks up the promotions behind promo codes.
+type PromotionCatalog interface {
+	// GetPromotion returns the promotion with code, or nil if there is none.
+	GetPromotion(ctx context.Context, code string) (*entities.Promotion, error)
+}
+
+// WithPromotions lets CreateOrder apply the promo codes known to promotions.
+// Without it every promo code is rejected as unknown.
+func WithPromotions(promotions PromotionCatalog) Option {
+	return func(uc *OrderUseCase) {
+		uc.promotions = promotions
+	}
+}
+
+// normalizePromoCode makes promo codes case-insensitive.
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// applyPromotion returns the discounts the promotion behind code gives on items
+// and the total that remains to be paid. Without a code there is no discount.
+func (uc *OrderUseCase) applyPromotion(ctx context.Context, code string, items []entities.Item, subtotal entities.Money) ([]entities.Discount, entities.Money, error) {
+	if code == "" {
+		return nil, subtotal, nil
+	}
+	if uc.promotions == nil {
+		return nil, entities.Money{}, fmt.Errorf("%w: %s", ErrUnknownPromoCode, code)
+	}
+
+	promotion, err := uc.promotions.GetPromotion(ctx, code)
+	if err != nil {
+		return nil, entities.Money{}, fmt.Errorf("failed to look up promo code: %w", err)
+	}
+	if promotion == nil {
+		return nil, entities.Money{}, fmt.Errorf("%w: %s", ErrUnknownPromoCode, code)
+	}
+
+	discounts := promotion.Apply(items, subtotal)
+	if len(discounts) == 0 {
+		return nil, entities.Money{}, fmt.Errorf("%w: %s", ErrPromoCodeNotApplicable, code)
+	}
+
+	total := subtotal
+	for _, discount := range discounts {
+		total.Amount -= discount.Amount.Amount
+	}
+	return discounts, total, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromotionCatalog struct {
+	mock.Mock
+}
+
+func (m *MockPromotionCatalog) GetPromotion(ctx context.Context, code string) (*entities.Promotion, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Promotion), args.Error(1)
+}
+
+func TestOrderUseCase_CreateOrder_PromoCode(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "WELCOME10").Return(&entities.Promotion{
+		Code:  "WELCOME10",
+		Rules: []entities.DiscountRule{entities.PercentageOff{Percent: 10}},
+	}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID:    "user123",
+		Items:     []entities.Item{{ProductID: "prod1", Quantity: 3, Price: rub(1000)}},
+		PromoCode: " welcome10 ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WELCOME10", order.PromoCode)
+	assert.Equal(t, rub(3000), order.Subtotal)
+	assert.Equal(t, []entities.Discount{{PromoCode: "WELCOME10", Description: "10% off", Amount: rub(300)}}, order.Discounts)
+	assert.Equal(t, rub(2700), order.TotalAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_WithoutPromoCode(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 3, Price: rub(1000)}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rub(3000), order.Subtotal)
+	assert.Equal(t, rub(3000), order.TotalAmount)
+	assert.Empty(t, order.Discounts)
+	promotions.AssertNotCalled(t, "GetPromotion", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_CreateOrder_PromoCodeRejected(t *testing.T) {
+	minBasket := &entities.Promotion{Code: "BIGBASKET", Rules: []entities.DiscountRule{
+		entities.MinBasket{Min: rub(100000), Rule: entities.FixedAmountOff{Amount: rub(5000)}},
+	}}
+
+	tests := []struct {
+		name       string
+		promotions PromotionCatalog
+		code       string
+		wantErr    error
+	}{
+		{
+			name: "unknown code",
+			promotions: func() PromotionCatalog {
+				m := new(MockPromotionCatalog)
+				m.On("GetPromotion", mock.Anything, "NOPE").Return(nil, nil)
+				return m
+			}(),
+			code:    "nope",
+			wantErr: ErrUnknownPromoCode,
+		},
+		{
+			name:    "no promotions configured",
+			code:    "WELCOME10",
+			wantErr: ErrUnknownPromoCode,
+		},
+		{
+			name: "minimum basket not reached",
+			promotions: func() PromotionCatalog {
+				m := new(MockPromotionCatalog)
+				m.On("GetPromotion", mock.Anything, "BIGBASKET").Return(minBasket, nil)
+				return m
+			}(),
+			code:    "BIGBASKET",
+			wantErr: ErrPromoCodeNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			var opts []Option
+			if tt.promotions != nil {
+				opts = append(opts, WithPromotions(tt.promotions))
+			}
+			useCase := NewOrderUseCase(mockRepo, opts...)
+
+			order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+				UserID:    "user123",
+				Items:     []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+				PromoCode: tt.code,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, order)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_CreateOrder_PromotionLookupError(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "WELCOME10").Return(nil, errors.New("connection refused"))
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions))
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID:    "user123",
+		Items:     []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+		PromoCode: "WELCOME10",
+	})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnknownPromoCode)
+	assert.Nil(t, order)
+}
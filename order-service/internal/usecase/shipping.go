@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+)
+
+// validateShipping checks the shipping details of an order and returns them
+// normalized: fields trimmed, the country code and delivery method upper-cased.
+// Without a delivery method, orders with an address are delivered by courier.
+// Orders that are picked up have no address. Orders with neither are accepted
+// for clients that predate shipping details.
+func validateShipping(address *entities.ShippingAddress, method entities.DeliveryMethod) (*entities.ShippingAddress, entities.DeliveryMethod, error) {
+	method = entities.DeliveryMethod(strings.ToUpper(strings.TrimSpace(string(method))))
+	if method == "" && address != nil {
+		method = entities.DeliveryMethodCourier
+	}
+	if method != "" && !entities.ValidDeliveryMethod(method) {
+		return nil, "", fieldError(ErrInvalidShippingAddress, "delivery_method", fmt.Sprintf("unknown delivery method %q", method))
+	}
+	if address != nil && method == entities.DeliveryMethodPickup {
+		return nil, "", fieldError(ErrInvalidShippingAddress, "shipping_address", fmt.Sprintf("must not be set for %s delivery", method))
+	}
+	if address == nil {
+		if method.RequiresAddress() {
+			return nil, "", fieldError(ErrInvalidShippingAddress, "shipping_address", fmt.Sprintf("is required for %s delivery", method))
+		}
+		return nil, method, nil
+	}
+
+	normalized := entities.ShippingAddress{
+		RecipientName: strings.TrimSpace(address.RecipientName),
+		Phone:         strings.TrimSpace(address.Phone),
+		Line1:         strings.TrimSpace(address.Line1),
+		Line2:         strings.TrimSpace(address.Line2),
+		City:          strings.TrimSpace(address.City),
+		State:         strings.TrimSpace(address.State),
+		PostalCode:    strings.TrimSpace(address.PostalCode),
+		CountryCode:   strings.ToUpper(strings.TrimSpace(address.CountryCode)),
+	}
+
+	fields := []struct {
+		name     string
+		value    string
+		required bool
+	}{
+		{"recipient_name", normalized.RecipientName, true},
+		{"phone", normalized.Phone, false},
+		{"line1", normalized.Line1, true},
+		{"line2", normalized.Line2, false},
+		{"city", normalized.City, true},
+		{"state", normalized.State, false},
+		{"postal_code", normalized.PostalCode, true},
+	}
+	var violations []FieldViolation
+	for _, field := range fields {
+		switch {
+		case field.required && field.value == "":
+			violations = append(violations, FieldViolation{"shipping_address." + field.name, "is required"})
+		case len(field.value) > MaxAddressFieldLength:
+			violations = append(violations, FieldViolation{"shipping_address." + field.name,
+				fmt.Sprintf("is longer than %d characters", MaxAddressFieldLength)})
+		}
+	}
+	if !validCountryCode(normalized.CountryCode) {
+		violations = append(violations, FieldViolation{"shipping_address.country_code",
+			fmt.Sprintf("must be an ISO 3166-1 alpha-2 code, got %q", address.CountryCode)})
+	}
+	if len(violations) > 0 {
+		return nil, "", &ValidationError{Err: ErrInvalidShippingAddress, Violations: violations}
+	}
+
+	return &normalized, method, nil
+}
+
+// validCountryCode reports whether code looks like an ISO 3166-1 alpha-2 code:
+// two upper-case Latin letters.
+func validCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateShippingAddressInput describes new shipping details for an order. An
+// empty DeliveryMethod keeps the order's current one; Address may only be nil
+// for orders that are picked up. If ExpectedVersion is non-nil the update only
+// succeeds while the order is still at that version.
+type UpdateShippingAddressInput struct {
+	OrderID         string
+	Address         *entities.ShippingAddress
+	DeliveryMethod  entities.DeliveryMethod
+	ExpectedVersion *int64
+}
+
+// UpdateShippingAddress replaces the shipping details of a pending order and
+// announces them with an order.shipping_updated event.
+func (uc *OrderUseCase) UpdateShippingAddress(ctx context.Context, input UpdateShippingAddressInput) (*entities.Order, error) {
+	if input.OrderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if input.Address == nil && input.DeliveryMethod == "" {
+		return nil, fmt.Errorf("%w: an address or a delivery method is required", ErrInvalidShippingAddress)
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order for shipping update: %w", err)
+	}
+
+	method := input.DeliveryMethod
+	if method == "" {
+		method = order.DeliveryMethod
+	}
+	address, method, err := validateShipping(input.Address, method)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return nil, fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, *input.ExpectedVersion, order.Version)
+	}
+	if order.Status != string(entities.OrderStatusPending) {
+		return nil, fmt.Errorf("%w: order is %s", ErrShippingNotEditable, order.Status)
+	}
+
+	update := entities.ShippingUpdate{
+		Address:        address,
+		DeliveryMethod: method,
+		UpdatedAt:      time.Now(),
+	}
+
+	currentVersion := order.Version
+	order.ShippingAddress = update.Address
+	order.DeliveryMethod = update.DeliveryMethod
+	order.UpdatedAt = update.UpdatedAt
+	order.Version = currentVersion + 1
+
+	event := newOrderEvent(ctx, entities.OrderEventShippingUpdated, order)
+	if err := uc.orderRepo.UpdateShipping(ctx, order.OrderID, update, currentVersion, event); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, fmt.Errorf("%w: order was modified concurrently", ErrVersionConflict)
+		}
+		return nil, fmt.Errorf("failed to update shipping details: %w", err)
+	}
+
+	uc.logger.InfoContext(ctx, "Order shipping details updated",
+		"order_id", order.OrderID,
+		"delivery_method", order.DeliveryMethod,
+		"version", order.Version)
+
+	return order, nil
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories"
+	"order-service/internal/domain/repositories/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func berlinAddress() *entities.ShippingAddress {
+	return &entities.ShippingAddress{
+		RecipientName: "Anna Schmidt",
+		Phone:         "+49 30 1234567",
+		Line1:         "Unter den Linden 1",
+		City:          "Berlin",
+		PostalCode:    "10117",
+		CountryCode:   "DE",
+	}
+}
+
+func TestOrderUseCase_CreateOrder_ShippingAddress(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	address := berlinAddress()
+	address.City = "  Berlin "
+	address.CountryCode = "de"
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID:          "user123",
+		Items:           []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+		ShippingAddress: address,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, berlinAddress(), order.ShippingAddress)
+	assert.Equal(t, entities.DeliveryMethodCourier, order.DeliveryMethod)
+	assert.Equal(t, "  Berlin ", address.City, "the input must not be modified")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_InvalidShipping(t *testing.T) {
+	tests := []struct {
+		name    string
+		address func(*entities.ShippingAddress)
+		method  entities.DeliveryMethod
+		noAddr  bool
+		field   string
+	}{
+		{name: "missing recipient", address: func(a *entities.ShippingAddress) { a.RecipientName = " " }, field: "shipping_address.recipient_name"},
+		{name: "missing postal code", address: func(a *entities.ShippingAddress) { a.PostalCode = "" }, field: "shipping_address.postal_code"},
+		{name: "country name instead of code", address: func(a *entities.ShippingAddress) { a.CountryCode = "Germany" }, field: "shipping_address.country_code"},
+		{name: "country code with digits", address: func(a *entities.ShippingAddress) { a.CountryCode = "D1" }, field: "shipping_address.country_code"},
+		{name: "field too long", address: func(a *entities.ShippingAddress) { a.Line2 = strings.Repeat("x", MaxAddressFieldLength+1) }, field: "shipping_address.line2"},
+		{name: "unknown method", method: "TELEPORT", field: "delivery_method"},
+		{name: "courier without address", method: entities.DeliveryMethodCourier, noAddr: true, field: "shipping_address"},
+		{name: "post without address", method: entities.DeliveryMethodPost, noAddr: true, field: "shipping_address"},
+		{name: "pickup with address", method: entities.DeliveryMethodPickup, field: "shipping_address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			useCase := NewOrderUseCase(mockRepo)
+
+			var address *entities.ShippingAddress
+			if !tt.noAddr {
+				address = berlinAddress()
+				if tt.address != nil {
+					tt.address(address)
+				}
+			}
+
+			order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+				UserID:          "user123",
+				Items:           []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+				ShippingAddress: address,
+				DeliveryMethod:  tt.method,
+			})
+			assert.ErrorIs(t, err, ErrInvalidShippingAddress)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.Len(t, validationErr.Violations, 1)
+			assert.Equal(t, tt.field, validationErr.Violations[0].Field)
+			assert.Nil(t, order)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_CreateOrder_PickupWithoutAddress(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID:         "user123",
+		Items:          []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000)}},
+		DeliveryMethod: "pickup",
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, order.ShippingAddress)
+	assert.Equal(t, entities.DeliveryMethodPickup, order.DeliveryMethod)
+}
+
+func TestOrderUseCase_UpdateShippingAddress(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{
+		OrderID:         "test-order",
+		UserID:          "user123",
+		Status:          "PENDING",
+		Version:         2,
+		ShippingAddress: berlinAddress(),
+		DeliveryMethod:  entities.DeliveryMethodCourier,
+	}, nil)
+
+	address := berlinAddress()
+	address.Line1 = "Alexanderplatz 5"
+	address.PostalCode = "10178"
+
+	mockRepo.On("UpdateShipping", mock.Anything, "test-order", mock.MatchedBy(func(u entities.ShippingUpdate) bool {
+		return u.DeliveryMethod == entities.DeliveryMethodCourier && u.Address.Line1 == "Alexanderplatz 5" && !u.UpdatedAt.IsZero()
+	}), int64(2), mock.MatchedBy(func(e *entities.OrderEvent) bool {
+		return e.Type == entities.OrderEventShippingUpdated && e.Order.Version == 3
+	})).Return(nil)
+
+	order, err := useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{
+		OrderID: "test-order",
+		Address: address,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "10178", order.ShippingAddress.PostalCode)
+	assert.Equal(t, entities.DeliveryMethodCourier, order.DeliveryMethod, "an empty method keeps the current one")
+	assert.Equal(t, int64(3), order.Version)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_UpdateShippingAddress_InvalidInput(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	_, err := useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{Address: berlinAddress()})
+	assert.ErrorIs(t, err, ErrInvalidOrderID)
+
+	_, err = useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{OrderID: "test-order"})
+	assert.ErrorIs(t, err, ErrInvalidShippingAddress)
+
+	// Switching a courier order to post keeps the address requirement.
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{
+		OrderID:        "test-order",
+		Status:         "PENDING",
+		Version:        1,
+		DeliveryMethod: entities.DeliveryMethodPickup,
+	}, nil)
+	_, err = useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{
+		OrderID:        "test-order",
+		DeliveryMethod: entities.DeliveryMethodPost,
+	})
+	assert.ErrorIs(t, err, ErrInvalidShippingAddress)
+
+	// A pickup order keeps its method, which takes no address.
+	_, err = useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{
+		OrderID: "test-order",
+		Address: berlinAddress(),
+	})
+	assert.ErrorIs(t, err, ErrInvalidShippingAddress)
+
+	mockRepo.AssertNotCalled(t, "UpdateShipping", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCase_UpdateShippingAddress_NotPending(t *testing.T) {
+	for _, status := range []string{"PAID", "FAILED", "CANCELLED", "REFUNDED"} {
+		t.Run(status, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+
+			useCase := NewOrderUseCase(mockRepo)
+
+			mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{OrderID: "test-order", Status: status, Version: 1}, nil)
+
+			order, err := useCase.UpdateShippingAddress(context.Background(), UpdateShippingAddressInput{
+				OrderID: "test-order",
+				Address: berlinAddress(),
+			})
+			assert.ErrorIs(t, err, ErrShippingNotEditable)
+			assert.Nil(t, order)
+
+			mockRepo.AssertNotCalled(t, "UpdateShipping", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_UpdateShippingAddress_VersionConflict(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+
+	useCase := NewOrderUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, "test-order").Return(&entities.Order{OrderID: "test-order", Status: "PENDING", Version: 3}, nil)
+
+	stale := int64(2)
+	order, err := useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{
+		OrderID:         "test-order",
+		Address:         berlinAddress(),
+		ExpectedVersion: &stale,
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+	mockRepo.AssertNotCalled(t, "UpdateShipping", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The order changes between the read and the write.
+	mockRepo.On("UpdateShipping", mock.Anything, "test-order", mock.Anything, int64(3), mock.Anything).Return(repositories.ErrVersionConflict)
+
+	order, err = useCase.UpdateShippingAddress(ctx, UpdateShippingAddressInput{
+		OrderID: "test-order",
+		Address: berlinAddress(),
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Nil(t, order)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"order-service/internal/domain/entities"
+)
+
+// TaxCalculator works out the tax levied on order lines.
+type TaxCalculator interface {
+	// CalculateTax returns the tax on each of lines, in the same order, for an
+	// order placed in region. It fails with entities.ErrNoTaxRule when no rule
+	// covers one of the lines.
+	CalculateTax(ctx context.Context, region string, lines []entities.TaxableLine) ([]entities.ItemTax, error)
+}
+
+// WithTaxCalculator makes CreateOrder levy tax on orders as calculator says.
+// Orders carry no tax by default.
+func WithTaxCalculator(calculator TaxCalculator) Option {
+	return func(uc *OrderUseCase) {
+		uc.taxCalculator = calculator
+	}
+}
+
+// normalizeRegion makes region codes such as "ru" or "de-by" case-insensitive.
+func normalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// taxResult is the tax levied on an order.
+type taxResult struct {
+	// items are the order items with their tax set.
+	items []entities.Item
+	// total is all tax levied; exclusive is the part of it added on top of
+	// the prices.
+	total     entities.Money
+	exclusive entities.Money
+}
+
+// applyTax works out the tax on items, leaving items itself untouched.
+// Discounts are spread over the items first, so that tax is levied on what the
+// customer actually pays.
+func (uc *OrderUseCase) applyTax(ctx context.Context, region string, items []entities.Item, discounts []entities.Discount, currency string) (*taxResult, error) {
+	result := &taxResult{
+		items:     items,
+		total:     entities.NewMoney(0, currency),
+		exclusive: entities.NewMoney(0, currency),
+	}
+	if uc.taxCalculator == nil {
+		return result, nil
+	}
+
+	amounts := make([]entities.Money, len(items))
+	for i, item := range items {
+		// Cannot overflow: calculateTotal has added up the same lines.
+		amounts[i], _ = item.Price.Mul(int64(item.Quantity))
+	}
+	discount := entities.NewMoney(0, currency)
+	for _, d := range discounts {
+		discount.Amount += d.Amount.Amount
+	}
+	amounts = entities.AllocateDiscount(amounts, discount)
+
+	lines := make([]entities.TaxableLine, len(items))
+	for i, item := range items {
+		lines[i] = entities.TaxableLine{Category: item.Category, Amount: amounts[i]}
+	}
+
+	taxes, err := uc.taxCalculator.CalculateTax(ctx, region, lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate tax: %w", err)
+	}
+	if len(taxes) != len(items) {
+		return nil, fmt.Errorf("failed to calculate tax: got %d lines for %d items", len(taxes), len(items))
+	}
+
+	result.items = make([]entities.Item, len(items))
+	for i, item := range items {
+		tax := taxes[i]
+		item.Tax = &tax
+		result.items[i] = item
+
+		// Rates are at most 100%, so the tax on a line never exceeds the line
+		// and these sums stay within the subtotal.
+		result.total.Amount += tax.Amount.Amount
+		if !tax.Inclusive {
+			result.exclusive.Amount += tax.Amount.Amount
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/domain/entities"
+	"order-service/internal/domain/repositories/repositorytest"
+	"order-service/internal/infrastructure/tax"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTaxCalculator struct {
+	mock.Mock
+}
+
+func (m *MockTaxCalculator) CalculateTax(ctx context.Context, region string, lines []entities.TaxableLine) ([]entities.ItemTax, error) {
+	args := m.Called(ctx, region, lines)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ItemTax), args.Error(1)
+}
+
+func TestOrderUseCase_CreateOrder_ExclusiveTaxAfterDiscount(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	promotions := new(MockPromotionCatalog)
+	promotions.On("GetPromotion", mock.Anything, "WELCOME10").Return(&entities.Promotion{
+		Code:  "WELCOME10",
+		Rules: []entities.DiscountRule{entities.PercentageOff{Percent: 10}},
+	}, nil)
+
+	// The 300 discount is spread over the lines in proportion to their amounts.
+	calculator := new(MockTaxCalculator)
+	calculator.On("CalculateTax", mock.Anything, "US-CA", []entities.TaxableLine{
+		{Category: "books", Amount: rub(1800)},
+		{Category: "toys", Amount: rub(900)},
+	}).Return([]entities.ItemTax{
+		{RateBasisPoints: 700, Amount: rub(126)},
+		{RateBasisPoints: 1900, Amount: rub(171)},
+	}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithPromotions(promotions), WithTaxCalculator(calculator))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	items := []entities.Item{
+		{ProductID: "prod1", Quantity: 2, Price: rub(1000), Category: "books"},
+		{ProductID: "prod2", Quantity: 1, Price: rub(1000), Category: "toys"},
+	}
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID:    "user123",
+		Items:     items,
+		PromoCode: "WELCOME10",
+		Region:    " us-ca ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "US-CA", order.Region)
+	assert.Equal(t, &entities.ItemTax{RateBasisPoints: 700, Amount: rub(126)}, order.Items[0].Tax)
+	assert.Equal(t, &entities.ItemTax{RateBasisPoints: 1900, Amount: rub(171)}, order.Items[1].Tax)
+	assert.Equal(t, rub(297), order.TaxAmount)
+	assert.Equal(t, rub(3000), order.Subtotal)
+	assert.Equal(t, rub(2997), order.TotalAmount)
+	assert.Nil(t, items[0].Tax, "input items must be left untouched")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_InclusiveTax(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	calculator := new(MockTaxCalculator)
+	calculator.On("CalculateTax", mock.Anything, "RU", []entities.TaxableLine{{Amount: rub(3600)}}).
+		Return([]entities.ItemTax{{RateBasisPoints: 2000, Inclusive: true, Amount: rub(600)}}, nil)
+
+	useCase := NewOrderUseCase(mockRepo, WithTaxCalculator(calculator))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 3, Price: rub(1200)}},
+		Region: "RU",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rub(600), order.TaxAmount)
+	assert.Equal(t, rub(3600), order.TotalAmount, "inclusive tax is already in the prices")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCase_CreateOrder_WithoutTaxCalculator(t *testing.T) {
+	mockRepo := new(repositorytest.MockOrderRepository)
+	useCase := NewOrderUseCase(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil)
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod1", Quantity: 3, Price: rub(1200)}},
+		Region: "RU",
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, order.Items[0].Tax)
+	assert.Equal(t, rub(0), order.TaxAmount)
+	assert.Equal(t, rub(3600), order.TotalAmount)
+}
+
+func TestOrderUseCase_CreateOrder_TaxRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "no rule", err: fmt.Errorf("%w for category \"toys\"", entities.ErrNoTaxRule), wantErr: entities.ErrNoTaxRule},
+		{name: "calculator failure", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repositorytest.MockOrderRepository)
+			calculator := new(MockTaxCalculator)
+			calculator.On("CalculateTax", mock.Anything, "XX", mock.Anything).Return(nil, tt.err)
+
+			useCase := NewOrderUseCase(mockRepo, WithTaxCalculator(calculator))
+
+			order, err := useCase.CreateOrder(context.Background(), CreateOrderInput{
+				UserID: "user123",
+				Items:  []entities.Item{{ProductID: "prod1", Quantity: 1, Price: rub(1000), Category: "toys"}},
+				Region: "xx",
+			})
+
+			assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NotErrorIs(t, err, entities.ErrNoTaxRule)
+			}
+			assert.Nil(t, order)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCase_CreateOrder_StaticTaxRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tax_rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"region": "DE", "category": "toys", "rate": 20},
+		{"region": "DE", "category": "books", "rate": 20, "inclusive": true}
+	]`), 0o600))
+	rules, err := tax.NewStaticRules(path)
+	require.NoError(t, err)
+
+	mockRepo := new(repositorytest.MockOrderRepository)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Order"), mock.AnythingOfType("*entities.OrderEvent")).
+		Return(nil).Once()
+
+	useCase := NewOrderUseCase(mockRepo, WithTaxCalculator(rules))
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items: []entities.Item{
+			{ProductID: "prod1", Quantity: 1, Price: rub(299), Category: "toys"},
+			{ProductID: "prod2", Quantity: 1, Price: rub(999), Category: "books"},
+		},
+		Region: "de",
+	})
+	require.NoError(t, err)
+
+	// 20% on top of 2.99 is 0.598 and 20% included in 9.99 is 1.665; both
+	// are rounded half away from zero.
+	assert.Equal(t, &entities.ItemTax{RateBasisPoints: 2000, Amount: rub(60)}, order.Items[0].Tax)
+	assert.Equal(t, &entities.ItemTax{RateBasisPoints: 2000, Inclusive: true, Amount: rub(167)}, order.Items[1].Tax)
+	assert.Equal(t, rub(1298), order.Subtotal)
+	assert.Equal(t, rub(227), order.TaxAmount)
+	assert.Equal(t, rub(1358), order.TotalAmount)
+
+	_, err = useCase.CreateOrder(ctx, CreateOrderInput{
+		UserID: "user123",
+		Items:  []entities.Item{{ProductID: "prod3", Quantity: 1, Price: rub(500), Category: "food"}},
+		Region: "DE",
+	})
+	assert.ErrorIs(t, err, entities.ErrNoTaxRule)
+	mockRepo.AssertExpectations(t)
+}